@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// openCheckpoint - Loads the checkpoint of --resume, or creates the checkpoint of a new scan next to its reports
+func openCheckpoint(resumePath, outputFile string) (*scanners.Checkpoint, error) {
+	if resumePath == "" {
+		checkpoint, err := scanners.NewCheckpoint(fmt.Sprintf("%s.checkpoint.jsonl", outputFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create checkpoint: %w", err)
+		}
+		return checkpoint, nil
+	}
+
+	checkpoint, err := scanners.LoadCheckpoint(resumePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	subscriptions, resourceGroups := 0, 0
+	for _, e := range checkpoint.Entries() {
+		if e.Subscription {
+			subscriptions++
+		} else {
+			resourceGroups++
+		}
+	}
+	log.Info().Msgf("Resuming from checkpoint %s: %d Resource Groups and %d subscriptions already scanned", resumePath, resourceGroups, subscriptions)
+	return checkpoint, nil
+}
+
+// saveCheckpoint - Appends a completed resource group or subscription to the checkpoint
+func saveCheckpoint(checkpoint *scanners.Checkpoint, entry scanners.CheckpointEntry) {
+	if err := checkpoint.Add(entry); err != nil {
+		log.Warn().Err(err).Msgf("Failed to write checkpoint %s. The scan can not be resumed", checkpoint.Path())
+	}
+}
+
+// closeCheckpoint - Deletes the checkpoint of a completed scan, or keeps it to resume a partial scan
+func closeCheckpoint(checkpoint *scanners.Checkpoint, completed bool) {
+	if completed {
+		if err := checkpoint.Remove(); err != nil {
+			log.Warn().Err(err).Msgf("Failed to delete checkpoint %s", checkpoint.Path())
+		}
+		return
+	}
+
+	if err := checkpoint.Close(); err != nil {
+		log.Warn().Err(err).Msgf("Failed to close checkpoint %s", checkpoint.Path())
+	}
+	log.Info().Msgf("Resume the scan with --resume %s", checkpoint.Path())
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// cloudMetadata - Cloud endpoints, in the format of the ARM metadata endpoint
+// (https://management.azure.com/metadata/endpoints?api-version=2022-09-01)
+type cloudMetadata struct {
+	Name            string `json:"name"`
+	ResourceManager string `json:"resourceManager"`
+	Authentication  struct {
+		LoginEndpoint string   `json:"loginEndpoint"`
+		Audiences     []string `json:"audiences"`
+	} `json:"authentication"`
+}
+
+// newCloudConfiguration - Returns the cloud selected with --cloud
+func newCloudConfiguration(cmd *cobra.Command) (cloud.Configuration, error) {
+	name, _ := cmd.Flags().GetString("cloud")
+
+	config, err := cloudConfiguration(name)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("invalid --cloud value: %w", err)
+	}
+	log.Debug().Msgf("Using Resource Manager endpoint %s", config.Services[cloud.ResourceManager].Endpoint)
+	return config, nil
+}
+
+// cloudConfiguration - Returns the configuration of a well known cloud, or reads it from a metadata file
+func cloudConfiguration(name string) (cloud.Configuration, error) {
+	switch strings.ToLower(name) {
+	case "", "azurecloud", "azurepubliccloud":
+		return cloud.AzurePublic, nil
+	case "azureusgovernment":
+		return cloud.AzureGovernment, nil
+	case "azurechinacloud":
+		return cloud.AzureChina, nil
+	}
+
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("%s is not AzureCloud, AzureUSGovernment, AzureChinaCloud or a cloud metadata file: %w", name, err)
+	}
+	return parseCloudMetadata(content)
+}
+
+// parseCloudMetadata - Parses a metadata document with a single cloud, or an array with a single cloud
+func parseCloudMetadata(content []byte) (cloud.Configuration, error) {
+	clouds := []cloudMetadata{}
+	if err := json.Unmarshal(content, &clouds); err != nil {
+		metadata := cloudMetadata{}
+		if err := json.Unmarshal(content, &metadata); err != nil {
+			return cloud.Configuration{}, err
+		}
+		clouds = append(clouds, metadata)
+	}
+
+	if len(clouds) != 1 {
+		return cloud.Configuration{}, fmt.Errorf("the cloud metadata file must describe a single cloud, found %d", len(clouds))
+	}
+
+	metadata := clouds[0]
+	if metadata.ResourceManager == "" || metadata.Authentication.LoginEndpoint == "" || len(metadata.Authentication.Audiences) == 0 {
+		return cloud.Configuration{}, errors.New("the cloud metadata file requires resourceManager, authentication.loginEndpoint and authentication.audiences")
+	}
+
+	return cloud.Configuration{
+		ActiveDirectoryAuthorityHost: metadata.Authentication.LoginEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Endpoint: metadata.ResourceManager,
+				Audience: metadata.Authentication.Audiences[0],
+			},
+		},
+	}, nil
+}
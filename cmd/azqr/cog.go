@@ -1,28 +0,0 @@
-// Copyright (c) Microsoft Corporation.
-// Licensed under the MIT License.
-
-package azqr
-
-import (
-	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/scanners/cog"
-	"github.com/spf13/cobra"
-)
-
-func init() {
-	scanCmd.AddCommand(cogCmd)
-}
-
-var cogCmd = &cobra.Command{
-	Use:   "cog",
-	Short: "Scan Azure Cognitive Service Accounts",
-	Long:  "Scan Azure Cognitive Service Accounts",
-	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		serviceScanners := []scanners.IAzureScanner{
-			&cog.CognitiveScanner{},
-		}
-
-		scan(cmd, serviceScanners)
-	},
-}
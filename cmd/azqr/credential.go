@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// Authentication modes supported by --auth-mode
+const (
+	authModeDefault          = "default"
+	authModeServicePrincipal = "sp-certificate"
+	authModeWorkloadIdentity = "workload-identity"
+	authModeManagedIdentity  = "managed-identity"
+	authModeAzureCLI         = "azure-cli"
+	authModeDeviceCode       = "device-code"
+)
+
+var authModes = []string{
+	authModeDefault,
+	authModeServicePrincipal,
+	authModeWorkloadIdentity,
+	authModeManagedIdentity,
+	authModeAzureCLI,
+	authModeDeviceCode,
+}
+
+// newCredential - Builds the credential selected with --auth-mode, --tenant-id and --client-id,
+// authenticating against the given cloud
+func newCredential(cmd *cobra.Command, cloudConfig cloud.Configuration) (azcore.TokenCredential, error) {
+	authMode, _ := cmd.Flags().GetString("auth-mode")
+	tenantID, _ := cmd.Flags().GetString("tenant-id")
+	clientID, _ := cmd.Flags().GetString("client-id")
+
+	cred, err := createCredential(strings.ToLower(authMode), tenantID, clientID, cloudConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Azure credentials: %w", err)
+	}
+	log.Debug().Msgf("Using %s authentication", authMode)
+	return cred, nil
+}
+
+// createCredential - Creates the azidentity credential of an authentication mode. The tenant and
+// client ids default to the AZURE_TENANT_ID and AZURE_CLIENT_ID environment variables. The Azure
+// CLI credential uses the cloud selected with az cloud set instead of cloudConfig.
+func createCredential(authMode, tenantID, clientID string, cloudConfig cloud.Configuration) (azcore.TokenCredential, error) {
+	if tenantID == "" {
+		tenantID = os.Getenv("AZURE_TENANT_ID")
+	}
+	if clientID == "" {
+		clientID = os.Getenv("AZURE_CLIENT_ID")
+	}
+
+	clientOptions := azcore.ClientOptions{Cloud: cloudConfig}
+
+	switch authMode {
+	case authModeDefault:
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: clientOptions,
+			TenantID:      tenantID,
+		})
+	case authModeServicePrincipal:
+		return newClientCertificateCredential(tenantID, clientID, clientOptions)
+	case authModeWorkloadIdentity:
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions: clientOptions,
+			TenantID:      tenantID,
+			ClientID:      clientID,
+		})
+	case authModeManagedIdentity:
+		options := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+		if clientID != "" {
+			options.ID = azidentity.ClientID(clientID)
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+	case authModeAzureCLI:
+		return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
+			TenantID: tenantID,
+		})
+	case authModeDeviceCode:
+		return azidentity.NewDeviceCodeCredential(&azidentity.DeviceCodeCredentialOptions{
+			ClientOptions: clientOptions,
+			TenantID:      tenantID,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported auth mode: %s (use %s)", authMode, strings.Join(authModes, ", "))
+	}
+}
+
+// newClientCertificateCredential - Creates a service principal credential with the PEM or PKCS12
+// certificate of the AZURE_CLIENT_CERTIFICATE_PATH and AZURE_CLIENT_CERTIFICATE_PASSWORD environment variables
+func newClientCertificateCredential(tenantID, clientID string, clientOptions azcore.ClientOptions) (azcore.TokenCredential, error) {
+	if tenantID == "" || clientID == "" {
+		return nil, fmt.Errorf("%s authentication requires --tenant-id and --client-id (or AZURE_TENANT_ID and AZURE_CLIENT_ID)", authModeServicePrincipal)
+	}
+
+	path := os.Getenv("AZURE_CLIENT_CERTIFICATE_PATH")
+	if path == "" {
+		return nil, fmt.Errorf("%s authentication requires the AZURE_CLIENT_CERTIFICATE_PATH environment variable", authModeServicePrincipal)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var password []byte
+	if v := os.Getenv("AZURE_CLIENT_CERTIFICATE_PASSWORD"); v != "" {
+		password = []byte(v)
+	}
+
+	certs, key, err := azidentity.ParseCertificates(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return azidentity.NewClientCertificateCredential(tenantID, clientID, certs, key, &azidentity.ClientCertificateCredentialOptions{
+		ClientOptions: clientOptions,
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	diffCmd.Flags().StringP("output-name", "o", "", "Output file name")
+	diffCmd.Flags().StringSliceP("output-format", "f", []string{"xlsx"}, "Output formats (xlsx, json, sarif, csv, html). Can be repeated")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Compare two scans",
+	Long:  "Compare two JSON reports (--output-format json) and report newly broken, fixed, suppressed and removed rules, new resources and removed resources",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		diff(cmd, args[0], args[1])
+	},
+}
+
+func diff(cmd *cobra.Command, oldPath, newPath string) {
+	outputFileName, _ := cmd.Flags().GetString("output-name")
+	outputFormats, _ := cmd.Flags().GetStringSlice("output-format")
+
+	if err := validateOutputFormats(outputFormats); err != nil {
+		log.Fatal().Err(err).Msg("Invalid output format")
+	}
+
+	oldReport, err := renderers.LoadJsonReport(oldPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load old report")
+	}
+
+	newReport, err := renderers.LoadJsonReport(newPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load new report")
+	}
+
+	outputFile := outputFileName
+	if outputFile == "" {
+		current_time := time.Now()
+		outputFileStamp := fmt.Sprintf("%d_%02d_%02d_T%02d%02d%02d",
+			current_time.Year(), current_time.Month(), current_time.Day(),
+			current_time.Hour(), current_time.Minute(), current_time.Second())
+
+		outputFile = fmt.Sprintf("%s_%s", "azqr_diff", outputFileStamp)
+	}
+
+	reportData := newReport.ToReportData()
+	reportData.OutputFileName = outputFile
+	reportData.ChangesData = renderers.DiffResults(oldReport.ToReportData().MainData, reportData.MainData)
+
+	printChanges(oldPath, newPath, reportData.ChangesData)
+
+	render(reportData, outputFormats)
+
+	log.Info().Msg("Diff completed.")
+}
+
+// printChanges - Prints a summary of the changes followed by one line per change
+func printChanges(oldPath, newPath string, changes []renderers.ChangeResult) {
+	counts := map[string]int{}
+	for _, c := range changes {
+		counts[c.Change]++
+	}
+
+	fmt.Printf("Changes from %s to %s\n", oldPath, newPath)
+	for _, k := range renderers.ChangeOrder {
+		fmt.Printf("  %-17s %d\n", k+":", counts[k])
+	}
+	fmt.Println()
+
+	for _, c := range changes {
+		if c.RuleId != "" {
+			fmt.Printf("%-17s %s %s (%s): %s\n", c.Change, c.ID, c.RuleId, c.Severity, c.Description)
+		} else {
+			fmt.Printf("%-17s %s\n", c.Change, c.ID)
+		}
+	}
+}
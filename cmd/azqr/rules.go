@@ -8,42 +8,11 @@ import (
 	"sort"
 
 	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/scanners/adf"
-	"github.com/Azure/azqr/internal/scanners/afd"
-	"github.com/Azure/azqr/internal/scanners/afw"
-	"github.com/Azure/azqr/internal/scanners/agw"
-	"github.com/Azure/azqr/internal/scanners/aks"
-	"github.com/Azure/azqr/internal/scanners/apim"
-	"github.com/Azure/azqr/internal/scanners/appcs"
-	"github.com/Azure/azqr/internal/scanners/appi"
-	"github.com/Azure/azqr/internal/scanners/cae"
-	"github.com/Azure/azqr/internal/scanners/ci"
-	"github.com/Azure/azqr/internal/scanners/cog"
-	"github.com/Azure/azqr/internal/scanners/cosmos"
-	"github.com/Azure/azqr/internal/scanners/cr"
-	"github.com/Azure/azqr/internal/scanners/dbw"
-	"github.com/Azure/azqr/internal/scanners/dec"
-	"github.com/Azure/azqr/internal/scanners/evgd"
-	"github.com/Azure/azqr/internal/scanners/evh"
-	"github.com/Azure/azqr/internal/scanners/kv"
-	"github.com/Azure/azqr/internal/scanners/lb"
-	"github.com/Azure/azqr/internal/scanners/logic"
-	"github.com/Azure/azqr/internal/scanners/maria"
-	"github.com/Azure/azqr/internal/scanners/mysql"
-	"github.com/Azure/azqr/internal/scanners/plan"
-	"github.com/Azure/azqr/internal/scanners/psql"
-	"github.com/Azure/azqr/internal/scanners/redis"
-	"github.com/Azure/azqr/internal/scanners/sb"
-	"github.com/Azure/azqr/internal/scanners/sigr"
-	"github.com/Azure/azqr/internal/scanners/sql"
-	"github.com/Azure/azqr/internal/scanners/st"
-	"github.com/Azure/azqr/internal/scanners/vm"
-	"github.com/Azure/azqr/internal/scanners/vnet"
-	"github.com/Azure/azqr/internal/scanners/wps"
 	"github.com/spf13/cobra"
 )
 
 func init() {
+	rulesCmd.Flags().StringP("custom-rules", "", "", "YAML or JSON file with custom rules to print with the built-in rules")
 	rootCmd.AddCommand(rulesCmd)
 }
 
@@ -52,51 +21,28 @@ var rulesCmd = &cobra.Command{
 	Short: "Print all azqr rules",
 	Long:  "Print all azqr rules as markdown table",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		serviceScanners := []scanners.IAzureScanner{
-			&dbw.DatabricksScanner{},
-			&adf.DataFactoryScanner{},
-			&afd.FrontDoorScanner{},
-			&afw.FirewallScanner{},
-			&agw.ApplicationGatewayScanner{},
-			&aks.AKSScanner{},
-			&apim.APIManagementScanner{},
-			&appcs.AppConfigurationScanner{},
-			&appi.AppInsightsScanner{},
-			&cae.ContainerAppsScanner{},
-			&ci.ContainerInstanceScanner{},
-			&cog.CognitiveScanner{},
-			&cosmos.CosmosDBScanner{},
-			&cr.ContainerRegistryScanner{},
-			&dec.DataExplorerScanner{},
-			&evgd.EventGridScanner{},
-			&evh.EventHubScanner{},
-			&kv.KeyVaultScanner{},
-			&lb.LoadBalancerScanner{},
-			&logic.LogicAppScanner{},
-			&maria.MariaScanner{},
-			&mysql.MySQLFlexibleScanner{},
-			&mysql.MySQLScanner{},
-			&plan.AppServiceScanner{},
-			&psql.PostgreFlexibleScanner{},
-			&psql.PostgreScanner{},
-			&redis.RedisScanner{},
-			&sb.ServiceBusScanner{},
-			&sigr.SignalRScanner{},
-			&sql.SQLScanner{},
-			&st.StorageScanner{},
-			&vm.VirtualMachineScanner{},
-			&vnet.VirtualNetworkScanner{},
-			&wps.WebPubSubScanner{},
+	// Execute prints the returned error
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rulesMaps := []map[string]scanners.AzureRule{}
+		for _, scanner := range newScanners(scanners.RegisteredScanners()) {
+			rulesMaps = append(rulesMaps, scanner.GetRules())
+		}
+
+		customRulesPath, _ := cmd.Flags().GetString("custom-rules")
+		if customRulesPath != "" {
+			customRules, err := loadCustomRules(customRulesPath)
+			if err != nil {
+				return err
+			}
+			rulesMaps = append(rulesMaps, customRules.GetRules())
 		}
 
 		fmt.Println("#  | Id | Category | Subcategory | Name | Severity | More Info")
 		fmt.Println("---|---|---|---|---|---|---")
 
 		i := 0
-		for _, scanner := range serviceScanners {
-			rulesMap := scanner.GetRules()
-
+		for _, rulesMap := range rulesMaps {
 			rules := map[string]scanners.AzureRule{}
 			for _, r := range rulesMap {
 				rules[r.Id] = r
@@ -115,5 +61,6 @@ var rulesCmd = &cobra.Command{
 				fmt.Println()
 			}
 		}
+		return nil
 	},
 }
@@ -5,45 +5,18 @@ package azqr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Azure/azqr/internal/ref"
 	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/scanners/adf"
-	"github.com/Azure/azqr/internal/scanners/afd"
-	"github.com/Azure/azqr/internal/scanners/afw"
-	"github.com/Azure/azqr/internal/scanners/agw"
-	"github.com/Azure/azqr/internal/scanners/aks"
-	"github.com/Azure/azqr/internal/scanners/apim"
-	"github.com/Azure/azqr/internal/scanners/appcs"
-	"github.com/Azure/azqr/internal/scanners/appi"
-	"github.com/Azure/azqr/internal/scanners/cae"
-	"github.com/Azure/azqr/internal/scanners/ci"
-	"github.com/Azure/azqr/internal/scanners/cog"
-	"github.com/Azure/azqr/internal/scanners/cosmos"
-	"github.com/Azure/azqr/internal/scanners/cr"
-	"github.com/Azure/azqr/internal/scanners/dbw"
-	"github.com/Azure/azqr/internal/scanners/dec"
-	"github.com/Azure/azqr/internal/scanners/evgd"
-	"github.com/Azure/azqr/internal/scanners/evh"
-	"github.com/Azure/azqr/internal/scanners/kv"
-	"github.com/Azure/azqr/internal/scanners/lb"
-	"github.com/Azure/azqr/internal/scanners/logic"
-	"github.com/Azure/azqr/internal/scanners/maria"
-	"github.com/Azure/azqr/internal/scanners/mysql"
-	"github.com/Azure/azqr/internal/scanners/plan"
-	"github.com/Azure/azqr/internal/scanners/psql"
-	"github.com/Azure/azqr/internal/scanners/redis"
-	"github.com/Azure/azqr/internal/scanners/sb"
-	"github.com/Azure/azqr/internal/scanners/sigr"
-	"github.com/Azure/azqr/internal/scanners/sql"
-	"github.com/Azure/azqr/internal/scanners/st"
-	"github.com/Azure/azqr/internal/scanners/vm"
-	"github.com/Azure/azqr/internal/scanners/vnet"
-	"github.com/Azure/azqr/internal/scanners/wps"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
@@ -51,7 +24,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
@@ -59,14 +31,40 @@ import (
 )
 
 func init() {
-	scanCmd.PersistentFlags().StringP("subscription-id", "s", "", "Azure Subscription Id")
-	scanCmd.PersistentFlags().StringP("resource-group", "g", "", "Azure Resource Group (Use with --subscription-id)")
+	scanCmd.PersistentFlags().StringSliceP("subscription-id", "s", []string{}, "Azure Subscription Id. Can be repeated")
+	scanCmd.PersistentFlags().StringP("subscriptions-file", "", "", "File with the Azure Subscription Ids to scan, one per line")
+	scanCmd.PersistentFlags().StringP("management-group", "", "", "Azure Management Group Id. Its subscriptions and the subscriptions of its descendants are scanned")
+	scanCmd.PersistentFlags().StringP("resource-group", "g", "", "Azure Resource Group (Use with a single --subscription-id)")
+	scanCmd.PersistentFlags().StringP("auth-mode", "", "default", "Authentication mode (default, sp-certificate, workload-identity, managed-identity, azure-cli, device-code)")
+	scanCmd.PersistentFlags().StringP("cloud", "", "AzureCloud", "Azure cloud (AzureCloud, AzureUSGovernment, AzureChinaCloud) or the path of a cloud metadata JSON file")
+	scanCmd.PersistentFlags().StringP("tenant-id", "", "", "Azure Tenant Id used to authenticate (defaults to AZURE_TENANT_ID)")
+	scanCmd.PersistentFlags().StringP("client-id", "", "", "Client Id of the service principal, workload identity or user assigned managed identity (defaults to AZURE_CLIENT_ID)")
 	scanCmd.PersistentFlags().BoolP("defender", "d", true, "Scan Defender Status")
 	scanCmd.PersistentFlags().BoolP("advisor", "a", true, "Scan Azure Advisor Recommendations")
 	scanCmd.PersistentFlags().BoolP("costs", "c", false, "Scan Azure Costs")
 	scanCmd.PersistentFlags().StringP("output-name", "o", "", "Output file name")
+	scanCmd.PersistentFlags().StringSliceP("output-format", "f", []string{"xlsx"}, "Output formats (xlsx, json, sarif, csv, html). Can be repeated")
 	scanCmd.PersistentFlags().BoolP("mask", "m", true, "Mask the subscription id in the report")
 	scanCmd.PersistentFlags().BoolP("debug", "", false, "Set log level to debug")
+	scanCmd.PersistentFlags().StringP("from-snapshot", "", "", "Evaluate the rules against exported ARM or Resource Graph JSON files in this directory instead of scanning Azure")
+	scanCmd.PersistentFlags().StringP("exclusions", "", "", "YAML or JSON file with the accepted findings to suppress")
+	scanCmd.PersistentFlags().StringP("custom-rules", "", "", "YAML or JSON file with custom rules to evaluate with the built-in rules")
+	scanCmd.PersistentFlags().StringSliceP("services", "", []string{}, "Scan only these services (e.g. kv,aks,st)")
+	scanCmd.PersistentFlags().StringSliceP("skip-services", "", []string{}, "Do not scan these services")
+	scanCmd.PersistentFlags().StringSliceP("rules", "", []string{}, "Evaluate only these rule Ids (wildcards allowed, e.g. st-*)")
+	scanCmd.PersistentFlags().StringSliceP("skip-rules", "", []string{}, "Do not evaluate these rule Ids (wildcards allowed)")
+	scanCmd.PersistentFlags().StringSliceP("categories", "", []string{}, "Evaluate only the rules of these categories (e.g. Security)")
+	scanCmd.PersistentFlags().StringP("min-severity", "", "", "Evaluate only the rules with this severity or higher (High, Medium, Low)")
+	scanCmd.PersistentFlags().BoolP("subscription-scope", "", false, "Find the resource types of each subscription with Resource Graph and scan only the matching services, listing resources once per subscription instead of once per resource group")
+	scanCmd.PersistentFlags().BoolP("fail-fast", "", false, "Stop the scan on the first error instead of reporting the errors and continuing")
+	scanCmd.PersistentFlags().StringP("resume", "", "", "Resume an interrupted scan from its checkpoint file, skipping the Resource Groups already scanned")
+	scanCmd.PersistentFlags().DurationP("timeout", "", 0, "Stop the scan after this duration (e.g. 30m) and write a partial report. 0 means no timeout")
+	scanCmd.PersistentFlags().IntP("parallelism", "", defaultParallelism, "Maximum number of scanners (and Diagnostic Settings batches) running at once")
+	scanCmd.PersistentFlags().StringP("fail-on", "", "", "Exit with code 2 when a broken rule has this severity or higher (High, Medium, Low)")
+
+	for _, name := range scanners.ServiceNames() {
+		scanCmd.AddCommand(newServiceScanCmd(name))
+	}
 
 	rootCmd.AddCommand(scanCmd)
 }
@@ -76,56 +74,128 @@ var scanCmd = &cobra.Command{
 	Short: "Scan Azure Resources",
 	Long:  "Scan Azure Resources",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		serviceScanners := []scanners.IAzureScanner{
-			&dbw.DatabricksScanner{},
-			&adf.DataFactoryScanner{},
-			&afd.FrontDoorScanner{},
-			&afw.FirewallScanner{},
-			&agw.ApplicationGatewayScanner{},
-			&aks.AKSScanner{},
-			&apim.APIManagementScanner{},
-			&appcs.AppConfigurationScanner{},
-			&appi.AppInsightsScanner{},
-			&cae.ContainerAppsScanner{},
-			&ci.ContainerInstanceScanner{},
-			&cog.CognitiveScanner{},
-			&cosmos.CosmosDBScanner{},
-			&cr.ContainerRegistryScanner{},
-			&dec.DataExplorerScanner{},
-			&evgd.EventGridScanner{},
-			&evh.EventHubScanner{},
-			&kv.KeyVaultScanner{},
-			&lb.LoadBalancerScanner{},
-			&logic.LogicAppScanner{},
-			&maria.MariaScanner{},
-			&mysql.MySQLFlexibleScanner{},
-			&mysql.MySQLScanner{},
-			&plan.AppServiceScanner{},
-			&psql.PostgreFlexibleScanner{},
-			&psql.PostgreScanner{},
-			&redis.RedisScanner{},
-			&sb.ServiceBusScanner{},
-			&sigr.SignalRScanner{},
-			&sql.SQLScanner{},
-			&st.StorageScanner{},
-			&vm.VirtualMachineScanner{},
-			&vnet.VirtualNetworkScanner{},
-			&wps.WebPubSubScanner{},
-		}
-		scan(cmd, serviceScanners)
+	// Execute prints the returned error
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scan(cmd, scanners.RegisteredScanners())
 	},
 }
 
-func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
-	subscriptionID, _ := cmd.Flags().GetString("subscription-id")
+// newServiceScanCmd - Creates the scan subcommand of a registered service
+func newServiceScanCmd(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name,
+		Short: fmt.Sprintf("Scan %s", scanners.ServiceDescription(name)),
+		Long:  fmt.Sprintf("Scan %s", scanners.ServiceDescription(name)),
+		Args:  cobra.NoArgs,
+		// Execute prints the returned error
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return scan(cmd, scanners.ServiceRegistrations(name))
+		},
+	}
+}
+
+// namedScanner - A scanner and the name of its service
+type namedScanner struct {
+	name    string
+	scanner scanners.IAzureScanner
+}
+
+// newScanners - Creates the scanners of the registrations
+func newScanners(registrations []scanners.ScannerRegistration) []scanners.IAzureScanner {
+	result := []scanners.IAzureScanner{}
+	for _, r := range registrations {
+		result = append(result, r.New())
+	}
+	return result
+}
+
+// selectScanners - Filters the registrations with --services and --skip-services, and drops
+// the scanners without any rule selected by the rule filter
+func selectScanners(registrations []scanners.ScannerRegistration, services, skipServices []string, filter *scanners.RuleFilter, customRules *scanners.CustomRules) ([]scanners.ScannerRegistration, error) {
+	for _, name := range append(append([]string{}, services...), skipServices...) {
+		if !scanners.IsRegisteredService(name) {
+			return nil, fmt.Errorf("unsupported service: %s (use %s)", name, strings.Join(scanners.ServiceNames(), ", "))
+		}
+	}
+
+	selected := []scanners.ScannerRegistration{}
+	for _, r := range registrations {
+		if (len(services) > 0 && !containsService(services, r.Name)) || containsService(skipServices, r.Name) {
+			continue
+		}
+		if !filter.MatchesAny(r.New().GetRules()) && !hasCustomRulesFor(customRules, r, filter) {
+			log.Debug().Msgf("Skipping %s: no rule selected", r.Name)
+			continue
+		}
+		selected = append(selected, r)
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no scanner selected by the service and rule filters")
+	}
+	return selected, nil
+}
+
+// filterByInventory - Drops the registrations without any resource in the inventory
+func filterByInventory(registrations []scanners.ScannerRegistration, inventory scanners.ResourceInventory) []scanners.ScannerRegistration {
+	result := []scanners.ScannerRegistration{}
+	for _, r := range registrations {
+		if inventory.Contains(r.ResourceTypes) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func containsService(services []string, name string) bool {
+	for _, s := range services {
+		if strings.EqualFold(s, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCustomRulesFor - Returns true if a custom rule selected by the filter targets a resource type of the scanner
+func hasCustomRulesFor(customRules *scanners.CustomRules, r scanners.ScannerRegistration, filter *scanners.RuleFilter) bool {
+	for _, t := range r.ResourceTypes {
+		if filter.MatchesAny(customRules.ForType(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+func scan(cmd *cobra.Command, registrations []scanners.ScannerRegistration) error {
+	subscriptionIDs, managementGroup, err := requestedSubscriptions(cmd)
+	if err != nil {
+		return err
+	}
 	resourceGroupName, _ := cmd.Flags().GetString("resource-group")
 	outputFileName, _ := cmd.Flags().GetString("output-name")
+	outputFormats, _ := cmd.Flags().GetStringSlice("output-format")
 	defender, _ := cmd.Flags().GetBool("defender")
 	advisor, _ := cmd.Flags().GetBool("advisor")
 	cost, _ := cmd.Flags().GetBool("costs")
 	mask, _ := cmd.Flags().GetBool("mask")
 	debug, _ := cmd.Flags().GetBool("debug")
+	snapshotPath, _ := cmd.Flags().GetString("from-snapshot")
+	exclusionsPath, _ := cmd.Flags().GetString("exclusions")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	customRulesPath, _ := cmd.Flags().GetString("custom-rules")
+	services, _ := cmd.Flags().GetStringSlice("services")
+	skipServices, _ := cmd.Flags().GetStringSlice("skip-services")
+	rules, _ := cmd.Flags().GetStringSlice("rules")
+	skipRules, _ := cmd.Flags().GetStringSlice("skip-rules")
+	categories, _ := cmd.Flags().GetStringSlice("categories")
+	minSeverity, _ := cmd.Flags().GetString("min-severity")
+	subscriptionScope, _ := cmd.Flags().GetBool("subscription-scope")
+	failFast, _ := cmd.Flags().GetBool("fail-fast")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	resumePath, _ := cmd.Flags().GetString("resume")
 
 	// Default level for this example is info, unless debug flag is present
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
@@ -134,8 +204,28 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 		log.Debug().Msg("Debug logging enabled")
 	}
 
-	if subscriptionID == "" && resourceGroupName != "" {
-		log.Fatal().Msg("Resource Group name can only be used with a Subscription Id")
+	if resourceGroupName != "" && (len(subscriptionIDs) != 1 || managementGroup != "") {
+		return errors.New("resource group name can only be used with a single subscription id")
+	}
+
+	if err := validateOutputFormats(outputFormats); err != nil {
+		return err
+	}
+
+	if parallelism < 1 {
+		return errors.New("parallelism must be greater than 0")
+	}
+
+	if timeout < 0 {
+		return errors.New("timeout can not be negative")
+	}
+
+	if failOn != "" {
+		severity, err := scanners.ParseSeverity(failOn)
+		if err != nil {
+			return fmt.Errorf("invalid --fail-on value: %w", err)
+		}
+		failOn = severity
 	}
 
 	outputFile := outputFileName
@@ -148,33 +238,88 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 		outputFile = fmt.Sprintf("%s_%s", "azqr_report", outputFileStamp)
 	}
 
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	var exclusions *scanners.Exclusions
+	if exclusionsPath != "" {
+		exclusions, err = scanners.LoadExclusions(exclusionsPath)
+		if err != nil {
+			return fmt.Errorf("failed to load exclusions: %w", err)
+		}
+	}
+
+	customRules, err := loadCustomRules(customRulesPath)
+	if err != nil {
+		return err
+	}
+
+	filter, err := scanners.NewRuleFilter(rules, skipRules, categories, minSeverity)
+	if err != nil {
+		return fmt.Errorf("invalid rule filter: %w", err)
+	}
+	registrations, err = selectScanners(registrations, services, skipServices, filter, customRules)
+	if err != nil {
+		return err
+	}
+
+	cloudConfig, err := newCloudConfiguration(cmd)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get Azure credentials")
+		return err
 	}
 
-	ctx := context.Background()
+	var snapshot *scanners.Snapshot
+	var cred azcore.TokenCredential
+	if snapshotPath != "" {
+		if managementGroup != "" {
+			return errors.New("management group can not be used when scanning from a snapshot")
+		}
+
+		// Usage errors were reported above, failures from here on are not the caller's mistake
+		cmd.SilenceUsage = true
+
+		snapshot, err = scanners.LoadSnapshot(snapshotPath)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot: %w", err)
+		}
+
+		if defender || advisor || cost {
+			log.Info().Msg("Defender, Advisor and Costs are not available when scanning from a snapshot. Skipping...")
+		}
+		defender, advisor, cost = false, false, false
+	} else {
+		// Usage errors were reported above, failures from here on are not the caller's mistake
+		cmd.SilenceUsage = true
+
+		cred, err = newCredential(cmd, cloudConfig)
+		if err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	ctx, cancel := newScanContext(timeout)
+	defer cancel()
 
 	clientOptions := &arm.ClientOptions{
 		ClientOptions: policy.ClientOptions{
+			Cloud: cloudConfig,
 			Retry: policy.RetryOptions{
 				RetryDelay:    20 * time.Millisecond,
 				MaxRetries:    3,
 				MaxRetryDelay: 10 * time.Minute,
 			},
+			PerRetryPolicies: []policy.Policy{scanners.NewRateLimiter(scanners.DefaultRateLimit, scanners.DefaultRateLimitBurst)},
 		},
 	}
+	pool := scanners.NewWorkerPool(parallelism)
 
-	subscriptions := []string{}
-	if subscriptionID != "" {
-		subscriptions = append(subscriptions, subscriptionID)
+	var subscriptions []string
+	if snapshot != nil {
+		subscriptions = subscriptionIDs
+		if len(subscriptions) == 0 {
+			subscriptions = snapshot.Subscriptions()
+		}
 	} else {
-		subs, err := listSubscriptions(ctx, cred, clientOptions)
+		subscriptions, err = resolveSubscriptions(ctx, cred, clientOptions, subscriptionIDs, managementGroup)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to list subscriptions")
-		}
-		for _, s := range subs {
-			subscriptions = append(subscriptions, *s.SubscriptionID)
+			return fmt.Errorf("failed to resolve subscriptions: %w", err)
 		}
 	}
 
@@ -185,176 +330,293 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 		Items: []*scanners.CostResultItem{},
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	defenderScanner := scanners.DefenderScanner{}
 	peScanner := scanners.PrivateEndpointScanner{}
 	pipScanner := scanners.PublicIPScanner{}
 	diagnosticsScanner := scanners.DiagnosticSettingsScanner{}
 	advisorScanner := scanners.AdvisorScanner{}
 	costScanner := scanners.CostScanner{}
+	inventoryScanner := scanners.ResourceInventoryScanner{}
+	scanErrors := &scanners.ScanErrors{FailFast: failFast}
+
+	checkpoint, err := openCheckpoint(resumePath, outputFile)
+	if err != nil {
+		return err
+	}
+	for _, e := range checkpoint.Entries() {
+		ruleResults = append(ruleResults, e.Results...)
+		scanErrors.Restore(e.Errors)
+		defenderResults = append(defenderResults, e.Defender...)
+		advisorResults = append(advisorResults, e.Advisor...)
+		if e.Costs != nil {
+			costResult.From = e.Costs.From
+			costResult.To = e.Costs.To
+			costResult.Items = append(costResult.Items, e.Costs.Items...)
+		}
+	}
 
 	for _, s := range subscriptions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if checkpoint.HasSubscription(s) {
+			log.Info().Msgf("Subscription %s was already scanned. Skipping...", s)
+			continue
+		}
+
 		resourceGroups := []string{}
-		if resourceGroupName != "" {
+		if subscriptionScope && resourceGroupName == "" {
+			// An empty Resource Group name makes the scanners list the whole subscription
+			resourceGroups = append(resourceGroups, "")
+		} else if snapshot != nil {
+			for _, rg := range snapshot.ResourceGroups(s) {
+				if resourceGroupName == "" || strings.EqualFold(rg, resourceGroupName) {
+					resourceGroups = append(resourceGroups, rg)
+				}
+			}
+
+			if resourceGroupName != "" && len(resourceGroups) == 0 {
+				return fmt.Errorf("resource group %s does not exist in the snapshot", resourceGroupName)
+			}
+		} else if resourceGroupName != "" {
 			exists, err := checkExistenceResourceGroup(ctx, s, resourceGroupName, cred, clientOptions)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to check existence of Resource Group")
+				return fmt.Errorf("failed to check existence of resource group %s: %w", resourceGroupName, err)
 			}
 
 			if !exists {
-				log.Fatal().Msgf("Resource Group %s does not exist", resourceGroupName)
+				return fmt.Errorf("resource group %s does not exist", resourceGroupName)
 			}
 			resourceGroups = append(resourceGroups, resourceGroupName)
 		} else {
 			rgs, err := listResourceGroup(ctx, s, cred, clientOptions)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to list Resource Groups")
+				scanErrors.Add(s, "", "Resource Groups", err)
+				continue
 			}
 			for _, rg := range rgs {
 				resourceGroups = append(resourceGroups, *rg.Name)
 			}
 		}
 
+		pendingResourceGroups := []string{}
+		for _, r := range resourceGroups {
+			if !checkpoint.HasResourceGroup(s, r) {
+				pendingResourceGroups = append(pendingResourceGroups, r)
+			}
+		}
+		if skipped := len(resourceGroups) - len(pendingResourceGroups); skipped > 0 {
+			log.Info().Msgf("Skipping %d Resource Groups already scanned in subscription %s", skipped, s)
+		}
+		resourceGroups = pendingResourceGroups
+
+		// Errors of the subscription wide scanners are saved with the checkpoint of the subscription
+		subscriptionErrors := []scanners.ScanError{}
+		addSubscriptionError := func(scanner string, err error) {
+			if e := scanErrors.Add(s, "", scanner, err); e != nil {
+				subscriptionErrors = append(subscriptionErrors, *e)
+			}
+		}
+
 		config := &scanners.ScannerConfig{
 			Ctx:            ctx,
 			SubscriptionID: s,
 			Cred:           cred,
 			ClientOptions:  clientOptions,
+			Snapshot:       snapshot,
+			Pool:           pool,
 		}
 
+		peResults := map[string]bool{}
 		err = peScanner.Init(config)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to initialize Private Endpoint Scanner")
+		if err == nil {
+			peResults, err = peScanner.ListResourcesWithPrivateEndpoints()
 		}
-		peResults, err := peScanner.ListResourcesWithPrivateEndpoints()
-		if err != nil {
-			if shouldSkipError(err) {
-				peResults = map[string]bool{}
-			} else {
-				log.Fatal().Err(err).Msg("Failed to list resources with Private Endpoints")
-			}
+		if err != nil && !shouldSkipError(err) {
+			addSubscriptionError("Private Endpoints", err)
+		}
+		if peResults == nil {
+			peResults = map[string]bool{}
 		}
 
+		diagResults := map[string]bool{}
+		workspaceResources := map[string]int{}
 		err = diagnosticsScanner.Init(config)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to initialize Diagnostic Settings Scanner")
+		if err == nil {
+			diagResults, workspaceResources, err = diagnosticsScanner.ListResourcesWithDiagnosticSettings()
 		}
-		diagResults, err := diagnosticsScanner.ListResourcesWithDiagnosticSettings()
-		if err != nil {
-			if shouldSkipError(err) {
-				diagResults = map[string]bool{}
-			} else {
-				log.Fatal().Err(err).Msg("Failed to list resources with Diagnostic Settings")
-			}
+		if err != nil && !shouldSkipError(err) {
+			addSubscriptionError("Diagnostic Settings", err)
+		}
+		if diagResults == nil {
+			diagResults = map[string]bool{}
+		}
+		if workspaceResources == nil {
+			workspaceResources = map[string]int{}
 		}
 
+		pips := map[string]*armnetwork.PublicIPAddress{}
 		err = pipScanner.Init(config)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to initialize Diagnostic Settings Scanner")
+		if err == nil {
+			pips, err = pipScanner.ListPublicIPs()
 		}
-		pips, err := pipScanner.ListPublicIPs()
-		if err != nil {
-			if shouldSkipError(err) {
-				pips = map[string]*armnetwork.PublicIPAddress{}
-			} else {
-				log.Fatal().Err(err).Msg("Failed to list Public IPs")
-			}
+		if err != nil && !shouldSkipError(err) {
+			addSubscriptionError("Public IPs", err)
+		}
+		if pips == nil {
+			pips = map[string]*armnetwork.PublicIPAddress{}
 		}
 
 		scanContext := scanners.ScanContext{
-			PrivateEndpoints:    peResults,
-			DiagnosticsSettings: diagResults,
-			PublicIPs:           pips,
+			PrivateEndpoints:       peResults,
+			DiagnosticsSettings:    diagResults,
+			WorkspaceResources:     workspaceResources,
+			PublicIPs:              pips,
+			CustomRules:            customRules,
+			Filter:                 filter,
+			VirtualNetworkGateways: &scanners.VirtualNetworkGateways{},
 		}
 
-		for _, a := range serviceScanners {
-			err := a.Init(config)
+		subscriptionRegistrations := registrations
+		if subscriptionScope {
+			var inventory scanners.ResourceInventory
+			err = inventoryScanner.Init(config)
+			if err == nil {
+				inventory, err = inventoryScanner.ListResourceTypes(resourceGroupName)
+			}
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to initialize scanner")
+				scanErrors.Add(s, resourceGroupName, "Resource Inventory", err)
+				continue
 			}
+			subscriptionRegistrations = filterByInventory(registrations, inventory)
+			log.Info().Msgf("Found resources for %d of %d scanners in subscription %s", len(subscriptionRegistrations), len(registrations), s)
 		}
 
-		for _, r := range resourceGroups {
-			log.Info().Msgf("Scanning Resource Group %s", r)
-			var wg sync.WaitGroup
-			ch := make(chan []scanners.AzureServiceResult, 5)
-			wg.Add(len(serviceScanners))
-
-			go func() {
-				wg.Wait()
-				close(ch)
-			}()
+		serviceScanners := []namedScanner{}
+		for _, r := range subscriptionRegistrations {
+			a := r.New()
+			if err := a.Init(config); err != nil {
+				addSubscriptionError(r.Name, err)
+				continue
+			}
+			serviceScanners = append(serviceScanners, namedScanner{name: r.Name, scanner: a})
+		}
 
-			for _, s := range serviceScanners {
-				go func(r string, s scanners.IAzureScanner) {
-					defer wg.Done()
+		if len(resourceGroups) == 1 && resourceGroups[0] == "" {
+			log.Info().Msgf("Scanning Subscription %s", s)
+		} else {
+			log.Info().Msgf("Scanning %d Resource Groups in subscription %s", len(resourceGroups), s)
+		}
 
-					res, err := retry(3, 10*time.Millisecond, s, r, &scanContext)
+		// Every scanner of every resource group is a task of the worker pool,
+		// so --parallelism bounds the requests sent at once to the subscription.
+		// The last scanner to complete a resource group saves it to the checkpoint.
+		var mutex sync.Mutex
+		tasks := []func(){}
+		for _, r := range resourceGroups {
+			entry := &scanners.CheckpointEntry{SubscriptionID: s, ResourceGroup: r}
+			remaining := len(serviceScanners)
+			for _, a := range serviceScanners {
+				r, a := r, a
+				tasks = append(tasks, func() {
+					if ctx.Err() != nil {
+						return
+					}
+					res, err := retry(ctx, 3, 10*time.Millisecond, a.scanner, r, &scanContext)
+					var scanError *scanners.ScanError
 					if err != nil {
-						cancel()
-						log.Fatal().Err(err).Msg("Failed to scan")
+						scanError = scanErrors.Add(s, r, a.name, err)
+						res = nil
+					} else {
+						exclusions.Apply(res)
+					}
+
+					mutex.Lock()
+					defer mutex.Unlock()
+					ruleResults = append(ruleResults, res...)
+					entry.Results = append(entry.Results, res...)
+					if scanError != nil {
+						entry.Errors = append(entry.Errors, *scanError)
 					}
-					ch <- res
-				}(r, s)
-			}
 
-			for i := 0; i < len(serviceScanners); i++ {
-				res := <-ch
-				ruleResults = append(ruleResults, res...)
+					remaining--
+					if remaining == 0 && ctx.Err() == nil {
+						saveCheckpoint(checkpoint, *entry)
+					}
+				})
 			}
 		}
+		pool.Run(tasks...)
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		var subscriptionDefender []scanners.DefenderResult
+		var subscriptionAdvisor []scanners.AdvisorResult
+		var subscriptionCosts *scanners.CostResult
 
 		if defender {
 			err = defenderScanner.Init(config)
-			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to initialize Defender Scanner")
+			if err == nil {
+				subscriptionDefender, err = defenderScanner.ListConfiguration()
+				defenderResults = append(defenderResults, subscriptionDefender...)
 			}
-
-			res, err := defenderScanner.ListConfiguration()
-			if err != nil {
-				if shouldSkipError(err) {
-					res = []scanners.DefenderResult{}
-				} else {
-					log.Fatal().Err(err).Msg("Failed to list Defender configuration")
-				}
+			if err != nil && !shouldSkipError(err) {
+				addSubscriptionError("Defender", err)
 			}
-			defenderResults = append(defenderResults, res...)
 		}
 
 		if advisor {
 			err = advisorScanner.Init(config)
-			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to initialize Advisor Scanner")
+			if err == nil {
+				subscriptionAdvisor, err = advisorScanner.ListRecommendations()
+				advisorResults = append(advisorResults, subscriptionAdvisor...)
 			}
-
-			rec, err := advisorScanner.ListRecommendations()
-			if err != nil {
-				if shouldSkipError(err) {
-					rec = []scanners.AdvisorResult{}
-				} else {
-					log.Fatal().Err(err).Msg("Failed to list Advisor recommendations")
-				}
+			if err != nil && !shouldSkipError(err) {
+				addSubscriptionError("Advisor", err)
 			}
-			advisorResults = append(advisorResults, rec...)
 		}
 
 		if cost {
 			err = costScanner.Init(config)
-			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to initialize Cost Scanner")
+			if err == nil {
+				subscriptionCosts, err = costScanner.QueryCosts()
+				if err == nil {
+					costResult.From = subscriptionCosts.From
+					costResult.To = subscriptionCosts.To
+					costResult.Items = append(costResult.Items, subscriptionCosts.Items...)
+				}
 			}
-			costs, err := costScanner.QueryCosts()
 			if err != nil && !shouldSkipError(err) {
-				log.Fatal().Err(err).Msg("Failed to query costs")
+				addSubscriptionError("Costs", err)
 			}
-			costResult.From = costs.From
-			costResult.To = costs.To
-			costResult.Items = append(costResult.Items, costs.Items...)
+		}
+
+		if ctx.Err() == nil {
+			saveCheckpoint(checkpoint, scanners.CheckpointEntry{
+				SubscriptionID: s,
+				Subscription:   true,
+				Errors:         subscriptionErrors,
+				Defender:       subscriptionDefender,
+				Advisor:        subscriptionAdvisor,
+				Costs:          subscriptionCosts,
+			})
 		}
 	}
 
+	partialReason := ""
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		partialReason = fmt.Sprintf("The scan timed out after %s", timeout)
+	case context.Canceled:
+		partialReason = "The scan was interrupted"
+	}
+	if partialReason != "" {
+		log.Warn().Msgf("%s. Writing a partial report with the results gathered so far.", partialReason)
+	}
+
 	reportData := renderers.ReportData{
 		OutputFileName: outputFile,
 		Mask:           mask,
@@ -362,20 +624,136 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 		DefenderData:   defenderResults,
 		AdvisorData:    advisorResults,
 		CostData:       costResult,
+		ErrorsData:     scanErrors.Errors(),
+		PartialReason:  partialReason,
+	}
+
+	render(reportData, outputFormats)
+	closeCheckpoint(checkpoint, partialReason == "")
+
+	if partialReason != "" {
+		log.Warn().Msg("Scan incomplete. The report is partial.")
+	} else if len(reportData.ErrorsData) > 0 {
+		log.Warn().Msgf("Scan completed with %d errors. See the Errors section of the report.", len(reportData.ErrorsData))
+	} else {
+		log.Info().Msg("Scan completed.")
+	}
+
+	summary := scanners.SummarizeResults(ruleResults)
+	summary.Errors = len(reportData.ErrorsData)
+	summary.Partial = partialReason != ""
+	printSummary(summary)
+
+	if failOn != "" && summary.HasBrokenAtLeast(failOn) {
+		log.Error().Msgf("Found broken rules with severity %s or higher", failOn)
+		os.Exit(exitCodeThreshold)
+	}
+
+	if summary.Partial {
+		os.Exit(1)
 	}
+	return nil
+}
 
-	renderers.CreateExcelReport(reportData)
+// newScanContext - Returns a context canceled by Ctrl-C (SIGINT), SIGTERM or, when timeout is not 0,
+// after timeout. Once the context is canceled, a second Ctrl-C exits immediately.
+func newScanContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	cancel := stop
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		cancel = func() {
+			cancelTimeout()
+			stop()
+		}
+	}
 
-	xslx := fmt.Sprintf("%s.xlsx", reportData.OutputFileName)
-	renderers.CreatePBIReport(xslx)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	log.Info().Msg("Scan completed.")
+	return ctx, cancel
 }
 
-func retry(attempts int, sleep time.Duration, a scanners.IAzureScanner, r string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+// exitCodeThreshold - Exit code used when --fail-on finds a broken rule. Fatal errors exit with 1.
+const exitCodeThreshold = 2
+
+// defaultParallelism - Default maximum number of scanners running at once
+const defaultParallelism = 10
+
+// printSummary - Prints the broken rule counts by Severity and Category as a single JSON line
+func printSummary(summary scanners.ResultSummary) {
+	content, err := json.Marshal(summary)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to marshal summary")
+	}
+	fmt.Println(string(content))
+}
+
+// loadCustomRules - Loads the custom rules file, if any, and checks their ids do not clash with built-in rules
+func loadCustomRules(path string) (*scanners.CustomRules, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	customRules, err := scanners.LoadCustomRules(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom rules: %w", err)
+	}
+
+	rules := customRules.GetRules()
+	for _, s := range newScanners(scanners.RegisteredScanners()) {
+		for id := range s.GetRules() {
+			if _, exists := rules[id]; exists {
+				return nil, fmt.Errorf("custom rule %s has the same id as a built-in rule", id)
+			}
+		}
+	}
+	return customRules, nil
+}
+
+func validateOutputFormats(formats []string) error {
+	if len(formats) == 0 {
+		return errors.New("at least one output format is required")
+	}
+	for _, f := range formats {
+		switch f {
+		case "xlsx", "json", "sarif", "csv", "html":
+		default:
+			return fmt.Errorf("unsupported output format: %s", f)
+		}
+	}
+	return nil
+}
+
+func render(reportData renderers.ReportData, formats []string) {
+	for _, f := range formats {
+		switch f {
+		case "xlsx":
+			renderers.CreateExcelReport(reportData)
+
+			xslx := fmt.Sprintf("%s.xlsx", reportData.OutputFileName)
+			renderers.CreatePBIReport(xslx)
+		case "json":
+			renderers.CreateJsonReport(reportData)
+		case "sarif":
+			renderers.CreateSarifReport(reportData)
+		case "csv":
+			renderers.CreateCsvReport(reportData)
+		case "html":
+			renderers.CreateHtmlReport(reportData)
+		}
+	}
+}
+
+// retry - Scans the resource group, retrying with an exponential backoff that stops when ctx is canceled
+func retry(ctx context.Context, attempts int, sleep time.Duration, a scanners.IAzureScanner, r string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
 	var err error
 	for i := 0; ; i++ {
-		res, err := a.Scan(r, scanContext)
+		var res []scanners.AzureServiceResult
+		res, err = a.Scan(r, scanContext)
 		if err == nil {
 			return res, nil
 		}
@@ -393,7 +771,11 @@ func retry(attempts int, sleep time.Duration, a scanners.IAzureScanner, r string
 
 		log.Debug().Msgf("Retrying after error: %s", errAsString)
 
-		time.Sleep(sleep)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sleep):
+		}
 		sleep *= 2
 	}
 	return nil, err
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+// Scanner packages register themselves with the scanners registry when imported.
+import (
+	_ "github.com/Azure/azqr/internal/scanners/adf"
+	_ "github.com/Azure/azqr/internal/scanners/afd"
+	_ "github.com/Azure/azqr/internal/scanners/afw"
+	_ "github.com/Azure/azqr/internal/scanners/agw"
+	_ "github.com/Azure/azqr/internal/scanners/aks"
+	_ "github.com/Azure/azqr/internal/scanners/apim"
+	_ "github.com/Azure/azqr/internal/scanners/appcs"
+	_ "github.com/Azure/azqr/internal/scanners/appi"
+	_ "github.com/Azure/azqr/internal/scanners/bas"
+	_ "github.com/Azure/azqr/internal/scanners/cae"
+	_ "github.com/Azure/azqr/internal/scanners/ci"
+	_ "github.com/Azure/azqr/internal/scanners/cog"
+	_ "github.com/Azure/azqr/internal/scanners/cosmos"
+	_ "github.com/Azure/azqr/internal/scanners/cr"
+	_ "github.com/Azure/azqr/internal/scanners/dbw"
+	_ "github.com/Azure/azqr/internal/scanners/dec"
+	_ "github.com/Azure/azqr/internal/scanners/disk"
+	_ "github.com/Azure/azqr/internal/scanners/ergw"
+	_ "github.com/Azure/azqr/internal/scanners/evgd"
+	_ "github.com/Azure/azqr/internal/scanners/evh"
+	_ "github.com/Azure/azqr/internal/scanners/kv"
+	_ "github.com/Azure/azqr/internal/scanners/law"
+	_ "github.com/Azure/azqr/internal/scanners/lb"
+	_ "github.com/Azure/azqr/internal/scanners/logic"
+	_ "github.com/Azure/azqr/internal/scanners/maria"
+	_ "github.com/Azure/azqr/internal/scanners/mysql"
+	_ "github.com/Azure/azqr/internal/scanners/nsg"
+	_ "github.com/Azure/azqr/internal/scanners/pip"
+	_ "github.com/Azure/azqr/internal/scanners/plan"
+	_ "github.com/Azure/azqr/internal/scanners/psql"
+	_ "github.com/Azure/azqr/internal/scanners/redis"
+	_ "github.com/Azure/azqr/internal/scanners/sb"
+	_ "github.com/Azure/azqr/internal/scanners/sigr"
+	_ "github.com/Azure/azqr/internal/scanners/sql"
+	_ "github.com/Azure/azqr/internal/scanners/st"
+	_ "github.com/Azure/azqr/internal/scanners/vm"
+	_ "github.com/Azure/azqr/internal/scanners/vmss"
+	_ "github.com/Azure/azqr/internal/scanners/vnet"
+	_ "github.com/Azure/azqr/internal/scanners/vpng"
+	_ "github.com/Azure/azqr/internal/scanners/vwan"
+	_ "github.com/Azure/azqr/internal/scanners/wps"
+)
@@ -0,0 +1,245 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	snapshotCmd.Flags().StringSliceP("subscription-id", "s", []string{}, "Azure Subscription Id. Can be repeated")
+	snapshotCmd.Flags().StringP("subscriptions-file", "", "", "File with the Azure Subscription Ids to scan, one per line")
+	snapshotCmd.Flags().StringP("management-group", "", "", "Azure Management Group Id. Its subscriptions and the subscriptions of its descendants are scanned")
+	snapshotCmd.Flags().StringP("resource-group", "g", "", "Azure Resource Group (Use with a single --subscription-id)")
+	snapshotCmd.Flags().StringP("auth-mode", "", "default", "Authentication mode (default, sp-certificate, workload-identity, managed-identity, azure-cli, device-code)")
+	snapshotCmd.Flags().StringP("cloud", "", "AzureCloud", "Azure cloud (AzureCloud, AzureUSGovernment, AzureChinaCloud) or the path of a cloud metadata JSON file")
+	snapshotCmd.Flags().StringP("tenant-id", "", "", "Azure Tenant Id used to authenticate (defaults to AZURE_TENANT_ID)")
+	snapshotCmd.Flags().StringP("client-id", "", "", "Client Id of the service principal, workload identity or user assigned managed identity (defaults to AZURE_CLIENT_ID)")
+	snapshotCmd.Flags().StringP("output-dir", "o", "", "Output directory for the snapshot files")
+	snapshotCmd.Flags().BoolP("debug", "", false, "Set log level to debug")
+	snapshotCmd.Flags().IntP("parallelism", "", defaultParallelism, "Maximum number of scanners (and Diagnostic Settings batches) running at once")
+	snapshotCmd.Flags().DurationP("timeout", "", 0, "Stop the capture after this duration (e.g. 30m). 0 means no timeout")
+
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture Azure Resources to a snapshot",
+	Long:  "Capture the raw payloads of the Azure Resources assessed by azqr to a directory of JSON files that can be scanned later with scan --from-snapshot",
+	Args:  cobra.NoArgs,
+	// Execute prints the returned error
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return snapshot(cmd, newScanners(scanners.RegisteredScanners()))
+	},
+}
+
+func snapshot(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) error {
+	subscriptionIDs, managementGroup, err := requestedSubscriptions(cmd)
+	if err != nil {
+		return err
+	}
+	resourceGroupName, _ := cmd.Flags().GetString("resource-group")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	debug, _ := cmd.Flags().GetBool("debug")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	if debug {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		log.Debug().Msg("Debug logging enabled")
+	}
+
+	if resourceGroupName != "" && (len(subscriptionIDs) != 1 || managementGroup != "") {
+		return errors.New("resource group name can only be used with a single subscription id")
+	}
+
+	if parallelism < 1 {
+		return errors.New("parallelism must be greater than 0")
+	}
+
+	if timeout < 0 {
+		return errors.New("timeout can not be negative")
+	}
+
+	// Usage errors were reported above, failures from here on are not the caller's mistake
+	cmd.SilenceUsage = true
+
+	if outputDir == "" {
+		current_time := time.Now()
+		outputDirStamp := fmt.Sprintf("%d_%02d_%02d_T%02d%02d%02d",
+			current_time.Year(), current_time.Month(), current_time.Day(),
+			current_time.Hour(), current_time.Minute(), current_time.Second())
+
+		outputDir = fmt.Sprintf("%s_%s", "azqr_snapshot", outputDirStamp)
+	}
+
+	recorder, err := scanners.NewSnapshotRecorder(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	cloudConfig, err := newCloudConfiguration(cmd)
+	if err != nil {
+		return err
+	}
+	cred, err := newCredential(cmd, cloudConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newScanContext(timeout)
+	defer cancel()
+
+	clientOptions := &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloudConfig,
+			Retry: policy.RetryOptions{
+				RetryDelay:    20 * time.Millisecond,
+				MaxRetries:    3,
+				MaxRetryDelay: 10 * time.Minute,
+			},
+			PerCallPolicies:  []policy.Policy{recorder},
+			PerRetryPolicies: []policy.Policy{scanners.NewRateLimiter(scanners.DefaultRateLimit, scanners.DefaultRateLimitBurst)},
+		},
+	}
+	pool := scanners.NewWorkerPool(parallelism)
+
+	subscriptions, err := resolveSubscriptions(ctx, cred, clientOptions, subscriptionIDs, managementGroup)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range subscriptions {
+		if err := captureSubscription(ctx, cancel, s, resourceGroupName, cred, clientOptions, pool, serviceScanners); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("snapshot timed out after %s: %w", timeout, err)
+			}
+			return err
+		}
+	}
+
+	if err := recorder.WriteManifest(version, subscriptions); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	log.Info().Msgf("Snapshot written to %s", outputDir)
+	return nil
+}
+
+// captureSubscription - Runs the scanners over the resource groups of a subscription so the recorder
+// keeps the payloads they list. The first scanner failure cancels ctx and is returned.
+func captureSubscription(ctx context.Context, cancel context.CancelFunc, subscriptionID, resourceGroupName string, cred azcore.TokenCredential, clientOptions *arm.ClientOptions, pool *scanners.WorkerPool, serviceScanners []scanners.IAzureScanner) error {
+	resourceGroups := []string{}
+	if resourceGroupName != "" {
+		exists, err := checkExistenceResourceGroup(ctx, subscriptionID, resourceGroupName, cred, clientOptions)
+		if err != nil {
+			return fmt.Errorf("failed to check existence of resource group %s: %w", resourceGroupName, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("resource group %s does not exist", resourceGroupName)
+		}
+		resourceGroups = append(resourceGroups, resourceGroupName)
+	} else {
+		rgs, err := listResourceGroup(ctx, subscriptionID, cred, clientOptions)
+		if err != nil {
+			return fmt.Errorf("failed to list resource groups of subscription %s: %w", subscriptionID, err)
+		}
+		for _, rg := range rgs {
+			resourceGroups = append(resourceGroups, *rg.Name)
+		}
+	}
+
+	config := &scanners.ScannerConfig{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+		Cred:           cred,
+		ClientOptions:  clientOptions,
+		Pool:           pool,
+	}
+
+	peScanner := scanners.PrivateEndpointScanner{}
+	if err := peScanner.Init(config); err != nil {
+		return fmt.Errorf("failed to initialize Private Endpoint Scanner: %w", err)
+	}
+	if _, err := peScanner.ListResourcesWithPrivateEndpoints(); err != nil && !shouldSkipError(err) {
+		return fmt.Errorf("failed to list resources with Private Endpoints: %w", err)
+	}
+
+	diagnosticsScanner := scanners.DiagnosticSettingsScanner{}
+	if err := diagnosticsScanner.Init(config); err != nil {
+		return fmt.Errorf("failed to initialize Diagnostic Settings Scanner: %w", err)
+	}
+	if _, _, err := diagnosticsScanner.ListResourcesWithDiagnosticSettings(); err != nil && !shouldSkipError(err) {
+		return fmt.Errorf("failed to list resources with Diagnostic Settings: %w", err)
+	}
+
+	pipScanner := scanners.PublicIPScanner{}
+	if err := pipScanner.Init(config); err != nil {
+		return fmt.Errorf("failed to initialize Public IP Scanner: %w", err)
+	}
+	pips, err := pipScanner.ListPublicIPs()
+	if err != nil && !shouldSkipError(err) {
+		return fmt.Errorf("failed to list Public IPs: %w", err)
+	}
+
+	// Only the payloads the scanners list are kept, so their rules are not evaluated.
+	// Public IPs are still passed on because the Public IP scanner lists them from the context.
+	scanContext := scanners.ScanContext{
+		PublicIPs:              pips,
+		Recording:              true,
+		VirtualNetworkGateways: &scanners.VirtualNetworkGateways{},
+	}
+
+	for _, a := range serviceScanners {
+		if err := a.Init(config); err != nil {
+			return fmt.Errorf("failed to initialize scanner: %w", err)
+		}
+	}
+
+	log.Info().Msgf("Capturing %d Resource Groups in subscription %s", len(resourceGroups), subscriptionID)
+
+	var mutex sync.Mutex
+	var firstErr error
+	tasks := []func(){}
+	for _, r := range resourceGroups {
+		for _, a := range serviceScanners {
+			r, a := r, a
+			tasks = append(tasks, func() {
+				if ctx.Err() != nil {
+					return
+				}
+				if _, err := retry(ctx, 3, 10*time.Millisecond, a, r, &scanContext); err != nil {
+					mutex.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to capture resource group %s: %w", r, err)
+					}
+					mutex.Unlock()
+					cancel()
+				}
+			})
+		}
+	}
+	pool.Run(tasks...)
+
+	if firstErr == nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return firstErr
+}
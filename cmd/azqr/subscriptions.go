@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// requestedSubscriptions - Returns the subscription ids passed with --subscription-id and
+// --subscriptions-file, and the management group passed with --management-group
+func requestedSubscriptions(cmd *cobra.Command) ([]string, string, error) {
+	subscriptionIDs, _ := cmd.Flags().GetStringSlice("subscription-id")
+	subscriptionsFile, _ := cmd.Flags().GetString("subscriptions-file")
+	managementGroup, _ := cmd.Flags().GetString("management-group")
+
+	if subscriptionsFile != "" {
+		ids, err := readSubscriptionsFile(subscriptionsFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read subscriptions file: %w", err)
+		}
+		subscriptionIDs = append(subscriptionIDs, ids...)
+	}
+
+	return uniqueSubscriptions(subscriptionIDs), managementGroup, nil
+}
+
+// readSubscriptionsFile - Reads one subscription id per line, ignoring empty lines and # comments
+func readSubscriptionsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ids := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line != "" {
+			ids = append(ids, line)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// resolveSubscriptions - Returns the requested subscriptions and the subscriptions of the
+// management group, or every enabled subscription when none is requested
+func resolveSubscriptions(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, subscriptionIDs []string, managementGroup string) ([]string, error) {
+	subscriptions := append([]string{}, subscriptionIDs...)
+
+	if managementGroup != "" {
+		subs, err := listManagementGroupSubscriptions(ctx, cred, options, managementGroup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the subscriptions of the Management Group: %w", err)
+		}
+		if len(subs) == 0 {
+			return nil, fmt.Errorf("no enabled subscription found in Management Group %s", managementGroup)
+		}
+		log.Info().Msgf("Found %d subscriptions in Management Group %s", len(subs), managementGroup)
+		subscriptions = append(subscriptions, subs...)
+	}
+
+	if len(subscriptions) == 0 {
+		subs, err := listSubscriptions(ctx, cred, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		for _, s := range subs {
+			subscriptions = append(subscriptions, *s.SubscriptionID)
+		}
+	}
+
+	return uniqueSubscriptions(subscriptions), nil
+}
+
+// listManagementGroupSubscriptions - Lists the enabled subscriptions of a management group and of
+// all its descendants, using the management group ancestors chain of Resource Graph
+func listManagementGroupSubscriptions(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, managementGroup string) ([]string, error) {
+	query := fmt.Sprintf(`resourcecontainers
+| where type == 'microsoft.resources/subscriptions' and properties.state == 'Enabled'
+| mv-expand managementGroup = properties.managementGroupAncestorsChain
+| where managementGroup.name =~ '%s'
+| distinct subscriptionId`, strings.ReplaceAll(managementGroup, "'", "\\'"))
+
+	graphQuery := scanners.GraphQuery{ClientOptions: options}
+	result, err := graphQuery.Query(ctx, cred, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := []string{}
+	for _, row := range result.Data {
+		m, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := m["subscriptionId"].(string); ok {
+			subscriptions = append(subscriptions, id)
+		}
+	}
+	return subscriptions, nil
+}
+
+// uniqueSubscriptions - Removes the duplicated (case insensitive) subscription ids, keeping their order
+func uniqueSubscriptions(subscriptionIDs []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, id := range subscriptionIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[strings.ToLower(id)] {
+			continue
+		}
+		seen[strings.ToLower(id)] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -1,28 +0,0 @@
-// Copyright (c) Microsoft Corporation.
-// Licensed under the MIT License.
-
-package azqr
-
-import (
-	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/scanners/vwan"
-	"github.com/spf13/cobra"
-)
-
-func init() {
-	scanCmd.AddCommand(vwanCmd)
-}
-
-var vwanCmd = &cobra.Command{
-	Use:   "vwan",
-	Short: "Scan Azure Virtual WAN",
-	Long:  "Scan Azure Virtual WAN",
-	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		serviceScanners := []scanners.IAzureScanner{
-			&vwan.VirtualWanScanner{},
-		}
-
-		scan(cmd, serviceScanners)
-	},
-}
@@ -17,12 +17,7 @@ func renderAdvisor(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to create Advisor sheet")
 		}
 
-		headers := data.AdvisorData[0].GetProperties()
-
-		rows := [][]string{}
-		for _, r := range data.AdvisorData {
-			rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
-		}
+		headers, rows := advisorTable(data)
 
 		createFirstRow(f, "Advisor", headers)
 
@@ -44,3 +39,13 @@ func renderAdvisor(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Advisor. No data to render")
 	}
 }
+
+func advisorTable(data ReportData) ([]string, [][]string) {
+	headers := data.AdvisorData[0].GetProperties()
+
+	rows := [][]string{}
+	for _, r := range data.AdvisorData {
+		rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
+	}
+	return headers, rows
+}
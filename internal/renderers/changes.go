@@ -0,0 +1,225 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	_ "image/png"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ChangeNewBroken       = "New Broken"
+	ChangeFixed           = "Fixed"
+	ChangeSuppressed      = "Suppressed"
+	ChangeRemovedRule     = "Removed Rule"
+	ChangeNewResource     = "New Resource"
+	ChangeRemovedResource = "Removed Resource"
+)
+
+// ChangeOrder - Order in which the kinds of change are listed
+var ChangeOrder = []string{
+	ChangeNewBroken,
+	ChangeFixed,
+	ChangeSuppressed,
+	ChangeRemovedRule,
+	ChangeNewResource,
+	ChangeRemovedResource,
+}
+
+// ChangeResult - A difference between two scans, for a resource or one of its rules
+type ChangeResult struct {
+	Change         string
+	SubscriptionID string
+	ResourceGroup  string
+	Type           string
+	ServiceName    string
+	ID             string
+	RuleId         string
+	Category       string
+	Severity       string
+	Description    string
+	Result         string
+}
+
+// DiffResults - Compares two result sets keyed by resource and rule Id. When only one of the
+// result sets has masked subscription ids, the other one is masked too so resources still match.
+func DiffResults(oldResults, newResults []scanners.AzureServiceResult) []ChangeResult {
+	oldMasked, newMasked := isMasked(oldResults), isMasked(newResults)
+	if oldMasked != newMasked {
+		log.Warn().Msg("Only one of the reports has masked subscription ids (--mask). Resources are compared by their masked ids")
+		if oldMasked {
+			newResults = maskResults(newResults)
+		} else {
+			oldResults = maskResults(oldResults)
+		}
+	}
+
+	oldResources := map[string]scanners.AzureServiceResult{}
+	for _, r := range oldResults {
+		oldResources[changeKey(r)] = r
+	}
+	newResources := map[string]bool{}
+
+	changes := []ChangeResult{}
+	for _, n := range newResults {
+		key := changeKey(n)
+		newResources[key] = true
+
+		o, exists := oldResources[key]
+		if !exists {
+			changes = append(changes, newResourceChange(ChangeNewResource, n, scanners.AzureRuleResult{}))
+		}
+
+		for _, nr := range n.Rules {
+			oldRule, ruleExists := o.Rules[nr.Id]
+			wasBroken := ruleExists && oldRule.IsBroken
+			switch {
+			case nr.IsBroken && !wasBroken:
+				changes = append(changes, newResourceChange(ChangeNewBroken, n, nr))
+			case wasBroken && nr.IsSuppressed:
+				changes = append(changes, newResourceChange(ChangeSuppressed, n, nr))
+			case wasBroken && !nr.IsBroken:
+				changes = append(changes, newResourceChange(ChangeFixed, n, nr))
+			}
+		}
+
+		// A broken rule missing from the new scan was removed or filtered out, it was not fixed
+		for _, oldRule := range o.Rules {
+			if _, ok := n.Rules[oldRule.Id]; !ok && oldRule.IsBroken {
+				changes = append(changes, newResourceChange(ChangeRemovedRule, n, oldRule))
+			}
+		}
+	}
+
+	for _, o := range oldResults {
+		if !newResources[changeKey(o)] {
+			changes = append(changes, newResourceChange(ChangeRemovedResource, o, scanners.AzureRuleResult{}))
+		}
+	}
+
+	order := map[string]int{}
+	for i, c := range ChangeOrder {
+		order[c] = i
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Change != changes[j].Change {
+			return order[changes[i].Change] < order[changes[j].Change]
+		}
+		if !strings.EqualFold(changes[i].ID, changes[j].ID) {
+			return strings.ToLower(changes[i].ID) < strings.ToLower(changes[j].ID)
+		}
+		return changes[i].RuleId < changes[j].RuleId
+	})
+
+	return changes
+}
+
+// changeKey - Identifies a resource across scans, falling back to its name for results without an id
+func changeKey(r scanners.AzureServiceResult) string {
+	if r.ID != "" {
+		return strings.ToLower(r.ID)
+	}
+	return strings.ToLower(strings.Join([]string{r.SubscriptionID, r.ResourceGroup, r.Type, r.ServiceName}, "/"))
+}
+
+// isMasked - Returns true if the subscription ids of the results were masked
+func isMasked(results []scanners.AzureServiceResult) bool {
+	for _, r := range results {
+		if r.SubscriptionID != "" {
+			return isMaskedSubscriptionID(r.SubscriptionID)
+		}
+	}
+	return false
+}
+
+// isMaskedSubscriptionID - A masked subscription id is left unchanged when masked again
+func isMaskedSubscriptionID(subscriptionID string) bool {
+	return len(subscriptionID) == 36 && scanners.MaskSubscriptionID(subscriptionID, true) == subscriptionID
+}
+
+// maskResults - Returns a copy of the results with masked subscription ids
+func maskResults(results []scanners.AzureServiceResult) []scanners.AzureServiceResult {
+	masked := make([]scanners.AzureServiceResult, 0, len(results))
+	for _, r := range results {
+		if len(r.SubscriptionID) == 36 && !isMaskedSubscriptionID(r.SubscriptionID) {
+			r.ID = scanners.MaskResourceID(r.ID, r.SubscriptionID, true)
+			r.SubscriptionID = scanners.MaskSubscriptionID(r.SubscriptionID, true)
+		}
+		masked = append(masked, r)
+	}
+	return masked
+}
+
+func newResourceChange(change string, r scanners.AzureServiceResult, rule scanners.AzureRuleResult) ChangeResult {
+	return ChangeResult{
+		Change:         change,
+		SubscriptionID: r.SubscriptionID,
+		ResourceGroup:  r.ResourceGroup,
+		Type:           r.Type,
+		ServiceName:    r.ServiceName,
+		ID:             r.ID,
+		RuleId:         rule.Id,
+		Category:       rule.Category,
+		Severity:       rule.Severity,
+		Description:    rule.Description,
+		Result:         rule.Result,
+	}
+}
+
+func renderChanges(f *excelize.File, data ReportData) {
+	if len(data.ChangesData) > 0 {
+		_, err := f.NewSheet("Changes")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create Changes sheet")
+		}
+
+		headers, rows := changesTable(data)
+
+		createFirstRow(f, "Changes", headers)
+
+		currentRow := 4
+		for _, row := range rows {
+			currentRow += 1
+			cell, err := excelize.CoordinatesToCellName(1, currentRow)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to get cell")
+			}
+			err = f.SetSheetRow("Changes", cell, &row)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to set row")
+			}
+		}
+
+		configureSheet(f, "Changes", headers, currentRow)
+	} else {
+		log.Info().Msg("Skipping Changes. No data to render")
+	}
+}
+
+func changesTable(data ReportData) ([]string, [][]string) {
+	headers := []string{"Change", "Subscription", "Resource Group", "Type", "Service Name", "Resource Id", "Rule Id", "Category", "Severity", "Description", "Result"}
+
+	rows := [][]string{}
+	for _, c := range data.ChangesData {
+		rows = append(rows, []string{
+			c.Change,
+			scanners.MaskSubscriptionID(c.SubscriptionID, data.Mask),
+			c.ResourceGroup,
+			c.Type,
+			c.ServiceName,
+			scanners.MaskResourceID(c.ID, c.SubscriptionID, data.Mask),
+			c.RuleId,
+			c.Category,
+			c.Severity,
+			c.Description,
+			c.Result,
+		})
+	}
+	return headers, rows
+}
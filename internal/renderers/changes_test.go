@@ -0,0 +1,205 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestDiffResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldResults []scanners.AzureServiceResult
+		newResults []scanners.AzureServiceResult
+		want       []string
+	}{
+		{
+			name:       "no changes",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true), rule("kv-002", false))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true), rule("kv-002", false))},
+			want:       []string{},
+		},
+		{
+			name:       "new broken",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", false))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			want:       []string{"New Broken kv-1 kv-001"},
+		},
+		{
+			name:       "new rule broken",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1")},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			want:       []string{"New Broken kv-1 kv-001"},
+		},
+		{
+			name:       "fixed",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", false))},
+			want:       []string{"Fixed kv-1 kv-001"},
+		},
+		{
+			name:       "suppressed",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", suppressed("kv-001"))},
+			want:       []string{"Suppressed kv-1 kv-001"},
+		},
+		{
+			name:       "still suppressed",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", suppressed("kv-001"))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", suppressed("kv-001"))},
+			want:       []string{},
+		},
+		{
+			name:       "exclusion expired",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", suppressed("kv-001"))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			want:       []string{"New Broken kv-1 kv-001"},
+		},
+		{
+			name:       "broken rule removed",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true), rule("kv-002", false))},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1")},
+			want:       []string{"Removed Rule kv-1 kv-001"},
+		},
+		{
+			name:       "new resource",
+			oldResults: []scanners.AzureServiceResult{},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true), rule("kv-002", false))},
+			want:       []string{"New Broken kv-1 kv-001", "New Resource kv-1 "},
+		},
+		{
+			name:       "removed resource",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			newResults: []scanners.AzureServiceResult{},
+			want:       []string{"Removed Resource kv-1 "},
+		},
+		{
+			name:       "resource id case",
+			oldResults: []scanners.AzureServiceResult{withID(testResult("kv-1", rule("kv-001", true)), "/subscriptions/"+testSubscriptionID+"/resourceGroups/RG1/providers/Microsoft.KeyVault/vaults/kv-1")},
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			want:       []string{},
+		},
+		{
+			name:       "resources without id",
+			oldResults: []scanners.AzureServiceResult{withID(testResult("kv-1", rule("kv-001", true)), "")},
+			newResults: []scanners.AzureServiceResult{withID(testResult("kv-1", rule("kv-001", false)), "")},
+			want:       []string{"Fixed kv-1 kv-001"},
+		},
+		{
+			name:       "old report masked",
+			oldResults: maskResults([]scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))}),
+			newResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", false))},
+			want:       []string{"Fixed kv-1 kv-001"},
+		},
+		{
+			name:       "new report masked",
+			oldResults: []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+			newResults: maskResults([]scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))}),
+			want:       []string{},
+		},
+		{
+			name: "order",
+			oldResults: []scanners.AzureServiceResult{
+				testResult("kv-2", rule("kv-001", true), rule("kv-002", true), rule("kv-003", true), rule("kv-004", true)),
+				testResult("kv-3"),
+			},
+			newResults: []scanners.AzureServiceResult{
+				testResult("kv-1", rule("kv-001", true)),
+				testResult("kv-2", rule("kv-001", false), suppressed("kv-002"), rule("kv-004", true), rule("kv-005", true)),
+			},
+			want: []string{
+				"New Broken kv-1 kv-001",
+				"New Broken kv-2 kv-005",
+				"Fixed kv-2 kv-001",
+				"Suppressed kv-2 kv-002",
+				"Removed Rule kv-2 kv-003",
+				"New Resource kv-1 ",
+				"Removed Resource kv-3 ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, c := range DiffResults(tt.oldResults, tt.newResults) {
+				got = append(got, c.Change+" "+c.ServiceName+" "+c.RuleId)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffResults_MaskedIds(t *testing.T) {
+	oldResults := maskResults([]scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))})
+	newResults := []scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", false))}
+
+	changes := DiffResults(oldResults, newResults)
+	if len(changes) != 1 || changes[0].SubscriptionID != testMaskedSubscriptionID || changes[0].ID != oldResults[0].ID {
+		t.Errorf("DiffResults() = %v, want a single change with masked ids", changes)
+	}
+	if newResults[0].SubscriptionID != testSubscriptionID {
+		t.Errorf("DiffResults() masked the results it was given: %v", newResults)
+	}
+}
+
+func TestChangesTable(t *testing.T) {
+	changes := DiffResults(
+		[]scanners.AzureServiceResult{testResult("kv-1")},
+		[]scanners.AzureServiceResult{testResult("kv-1", rule("kv-001", true))},
+	)
+
+	tests := []struct {
+		name string
+		mask bool
+		want []string
+	}{
+		{"unmasked", false, []string{ChangeNewBroken, testSubscriptionID, "rg1", "Microsoft.KeyVault/vaults", "kv-1", testResourceID, "kv-001"}},
+		{"masked", true, []string{ChangeNewBroken, testMaskedSubscriptionID, "rg1", "Microsoft.KeyVault/vaults", "kv-1", "/subscriptions/" + testMaskedSubscriptionID + "/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-1", "kv-001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, rows := changesTable(ReportData{ChangesData: changes, Mask: tt.mask})
+			if !reflect.DeepEqual(headers[5:7], []string{"Resource Id", "Rule Id"}) {
+				t.Errorf("changesTable() headers = %v, want the Resource Id and Rule Id columns", headers)
+			}
+			if len(rows) != 1 || !reflect.DeepEqual(rows[0][:7], tt.want) {
+				t.Errorf("changesTable() rows = %v, want %v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func testResult(name string, rules ...scanners.AzureRuleResult) scanners.AzureServiceResult {
+	r := scanners.AzureServiceResult{
+		SubscriptionID: testSubscriptionID,
+		ResourceGroup:  "rg1",
+		Location:       "westeurope",
+		Type:           "Microsoft.KeyVault/vaults",
+		ServiceName:    name,
+		ID:             "/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/" + name,
+		Rules:          map[string]scanners.AzureRuleResult{},
+	}
+	for _, rr := range rules {
+		r.Rules[rr.Id] = rr
+	}
+	return r
+}
+
+func withID(r scanners.AzureServiceResult, id string) scanners.AzureServiceResult {
+	r.ID = id
+	return r
+}
+
+func rule(id string, broken bool) scanners.AzureRuleResult {
+	return scanners.AzureRuleResult{Id: id, Severity: scanners.SeverityMedium, IsBroken: broken}
+}
+
+func suppressed(id string) scanners.AzureRuleResult {
+	return scanners.AzureRuleResult{Id: id, Severity: scanners.SeverityMedium, IsSuppressed: true}
+}
@@ -18,12 +18,7 @@ func renderCosts(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to create Costs sheet")
 		}
 
-		headers := data.CostData.GetProperties()
-
-		rows := [][]string{}
-		for _, r := range data.CostData.Items {
-			rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
-		}
+		headers, rows := costsTable(data)
 
 		createFirstRow(f, "Costs", headers)
 
@@ -58,3 +53,13 @@ func renderCosts(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Costs. No data to render")
 	}
 }
+
+func costsTable(data ReportData) ([]string, [][]string) {
+	headers := data.CostData.GetProperties()
+
+	rows := [][]string{}
+	for _, r := range data.CostData.Items {
+		rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
+	}
+	return headers, rows
+}
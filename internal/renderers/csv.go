@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreateCsvReport - Writes one CSV file per sheet of the Excel report
+func CreateCsvReport(data ReportData) {
+	if len(data.MainData) > 0 {
+		writeCsv(data, "Overview", overviewTable)
+		writeCsv(data, "Recommendations", recommendationsTable)
+		writeCsv(data, "Services", servicesTable)
+	} else {
+		log.Info().Msg("Skipping Overview, Recommendations and Services CSV. No data to render")
+	}
+
+	if len(data.DefenderData) > 0 {
+		writeCsv(data, "Defender", defenderTable)
+	} else {
+		log.Info().Msg("Skipping Defender CSV. No data to render")
+	}
+
+	if len(data.AdvisorData) > 0 {
+		writeCsv(data, "Advisor", advisorTable)
+	} else {
+		log.Info().Msg("Skipping Advisor CSV. No data to render")
+	}
+
+	if data.CostData != nil && len(data.CostData.Items) > 0 {
+		writeCsv(data, "Costs", costsTable)
+	} else {
+		log.Info().Msg("Skipping Costs CSV. No data to render")
+	}
+
+	if len(data.ChangesData) > 0 {
+		writeCsv(data, "Changes", changesTable)
+	} else {
+		log.Info().Msg("Skipping Changes CSV. No data to render")
+	}
+
+	if data.hasErrors() {
+		writeCsv(data, "Errors", errorsTable)
+	}
+}
+
+func writeCsv(data ReportData, sheet string, table func(data ReportData) ([]string, [][]string)) {
+	filename := fmt.Sprintf("%s.%s.csv", data.OutputFileName, strings.ToLower(sheet))
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to create %s CSV file", sheet)
+	}
+	defer f.Close()
+
+	headers, rows := table(data)
+
+	w := csv.NewWriter(f)
+	if err := w.Write(headers); err != nil {
+		log.Fatal().Err(err).Msgf("Failed to write %s CSV headers", sheet)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		log.Fatal().Err(err).Msgf("Failed to write %s CSV rows", sheet)
+	}
+}
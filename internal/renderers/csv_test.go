@@ -0,0 +1,150 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCreateCsvReport(t *testing.T) {
+	data := testReportData()
+	data.OutputFileName = filepath.Join(t.TempDir(), "azqr_report")
+	data.ChangesData = []ChangeResult{
+		{
+			Change:         ChangeNewBroken,
+			SubscriptionID: testSubscriptionID,
+			ResourceGroup:  "rg1",
+			Type:           "Microsoft.KeyVault/vaults",
+			ServiceName:    "kv-1",
+			ID:             testResourceID,
+			RuleId:         "kv-001",
+			Category:       "Reliability",
+			Severity:       "Medium",
+			Description:    "Key Vault should have diagnostic settings enabled",
+		},
+	}
+
+	CreateCsvReport(data)
+
+	tests := []struct {
+		sheet string
+		want  [][]string
+	}{
+		{
+			sheet: "overview",
+			want: [][]string{
+				{"SubscriptionID", "ResourceGroup", "Location", "Type", "Name", "SKU", "SLA", "AZ", "PVT", "DS", "CAF"},
+				{testSubscriptionID, "rg1", "westeurope", "Microsoft.KeyVault/vaults", "kv-1", "", "", "", "", "", ""},
+			},
+		},
+		{
+			sheet: "recommendations",
+			want: [][]string{
+				{"Id", "Category", "Subcategory", "Description", "Severity", "Learn"},
+			},
+		},
+		{
+			sheet: "services",
+			want: [][]string{
+				{"Subscription", "Resource Group", "Location", "Type", "Service Name", "Broken", "Category", "Subcategory", "Severity", "Description", "Result", "Learn", "Suppressed", "Justification"},
+			},
+		},
+		{
+			sheet: "defender",
+			want: [][]string{
+				{"SubscriptionID", "Name", "Tier", "Deprecated"},
+				{testSubscriptionID, "KeyVaults", "Standard", "false"},
+			},
+		},
+		{
+			sheet: "advisor",
+			want: [][]string{
+				{"SubscriptionID", "Name", "Type", "Category", "Description", "PotentialBenefits", "Risk", "LearnMoreLink"},
+				{testSubscriptionID, "kv-1", "Microsoft.KeyVault/vaults", "HighAvailability", "Enable soft delete", "Recover deleted secrets", "Warning", "https://learn.microsoft.com/advisor"},
+			},
+		},
+		{
+			sheet: "costs",
+			want: [][]string{
+				{"SubscriptionID", "ServiceName", "Value", "Currency"},
+				{testSubscriptionID, "Key Vault", "1.23", "EUR"},
+			},
+		},
+		{
+			sheet: "changes",
+			want: [][]string{
+				{"Change", "Subscription", "Resource Group", "Type", "Service Name", "Resource Id", "Rule Id", "Category", "Severity", "Description", "Result"},
+				{ChangeNewBroken, testSubscriptionID, "rg1", "Microsoft.KeyVault/vaults", "kv-1", testResourceID, "kv-001", "Reliability", "Medium", "Key Vault should have diagnostic settings enabled", ""},
+			},
+		},
+		{
+			sheet: "errors",
+			want: [][]string{
+				{"Subscription", "Resource Group", "Scanner", "Error"},
+				{testSubscriptionID, "rg2", "st", "403 on " + testResourceID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sheet, func(t *testing.T) {
+			records := readCsv(t, data.OutputFileName+"."+tt.sheet+".csv")
+
+			switch tt.sheet {
+			case "recommendations":
+				// Broken rules are listed once
+				if len(records) != 2 || records[1][0] != "kv-001" {
+					t.Fatalf("%s CSV = %v, want kv-001", tt.sheet, records)
+				}
+				records = records[:1]
+			case "services":
+				// Broken rules come first
+				if len(records) != 4 || records[1][9] != "Key Vault should have diagnostic settings enabled" || records[1][5] != "true" || records[2][5] != "false" || records[3][5] != "false" {
+					t.Fatalf("%s CSV = %v, want the broken kv-001 rule first", tt.sheet, records)
+				}
+				records = records[:1]
+			}
+
+			if !reflect.DeepEqual(records, tt.want) {
+				t.Errorf("%s CSV = %v, want %v", tt.sheet, records, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCsvReport_Masked(t *testing.T) {
+	data := testReportData()
+	data.OutputFileName = filepath.Join(t.TempDir(), "azqr_report")
+	data.Mask = true
+
+	CreateCsvReport(data)
+
+	records := readCsv(t, data.OutputFileName+".errors.csv")
+	want := []string{testMaskedSubscriptionID, "rg2", "st", "403 on /subscriptions/" + testMaskedSubscriptionID + "/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-1"}
+	if len(records) != 2 || !reflect.DeepEqual(records[1], want) {
+		t.Errorf("errors CSV = %v, want %v", records, want)
+	}
+
+	// Sheets without data are skipped
+	if _, err := os.Stat(data.OutputFileName + ".changes.csv"); !os.IsNotExist(err) {
+		t.Errorf("changes CSV should not be written without changes, got error %v", err)
+	}
+}
+
+func readCsv(t *testing.T, path string) [][]string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
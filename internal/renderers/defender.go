@@ -17,12 +17,7 @@ func renderDefender(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to create Defender sheet")
 		}
 
-		headers := data.DefenderData[0].GetProperties()
-
-		rows := [][]string{}
-		for _, r := range data.DefenderData {
-			rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
-		}
+		headers, rows := defenderTable(data)
 
 		createFirstRow(f, "Defender", headers)
 
@@ -44,3 +39,13 @@ func renderDefender(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Defender. No data to render")
 	}
 }
+
+func defenderTable(data ReportData) ([]string, [][]string) {
+	headers := data.DefenderData[0].GetProperties()
+
+	rows := [][]string{}
+	for _, r := range data.DefenderData {
+		rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
+	}
+	return headers, rows
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	_ "image/png"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+func renderErrors(f *excelize.File, data ReportData) {
+	if data.hasErrors() {
+		_, err := f.NewSheet("Errors")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create Errors sheet")
+		}
+
+		headers, rows := errorsTable(data)
+
+		createFirstRow(f, "Errors", headers)
+
+		currentRow := 4
+		for _, row := range rows {
+			currentRow += 1
+			cell, err := excelize.CoordinatesToCellName(1, currentRow)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to get cell")
+			}
+			err = f.SetSheetRow("Errors", cell, &row)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to set row")
+			}
+		}
+
+		configureSheet(f, "Errors", headers, currentRow)
+	}
+}
+
+func errorsTable(data ReportData) ([]string, [][]string) {
+	headers := []string{"Subscription", "Resource Group", "Scanner", "Error"}
+
+	rows := [][]string{}
+	if data.PartialReason != "" {
+		rows = append(rows, []string{"", "", "azqr", data.PartialReason + ". The report is partial"})
+	}
+	for _, e := range data.ErrorsData {
+		rows = append(rows, []string{
+			scanners.MaskSubscriptionID(e.SubscriptionID, data.Mask),
+			e.ResourceGroup,
+			e.Scanner,
+			scanners.MaskResourceID(e.Error, e.SubscriptionID, data.Mask),
+		})
+	}
+	return headers, rows
+}
+
+// renderPartial - Writes a warning above the table of every sheet of a partial report
+func renderPartial(f *excelize.File, data ReportData) {
+	if data.PartialReason == "" {
+		return
+	}
+
+	style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Color: "A4262C"}})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create style")
+	}
+
+	for _, sheet := range f.GetSheetList() {
+		if err := f.SetCellValue(sheet, "A1", "Partial report: "+data.PartialReason); err != nil {
+			log.Fatal().Err(err).Msg("Failed to set cell")
+		}
+		if err := f.SetCellStyle(sheet, "A1", "A1", style); err != nil {
+			log.Fatal().Err(err).Msg("Failed to set style")
+		}
+	}
+}
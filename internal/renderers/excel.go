@@ -29,6 +29,9 @@ func CreateExcelReport(data ReportData) {
 	renderDefender(f, data)
 	renderAdvisor(f, data)
 	renderCosts(f, data)
+	renderChanges(f, data)
+	renderErrors(f, data)
+	renderPartial(f, data)
 
 	if err := f.SaveAs(filename); err != nil {
 		log.Fatal().Err(err).Msg("Failed to save Excel file")
@@ -0,0 +1,231 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/embeded"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	htmlTable struct {
+		Headers []string
+		Rows    [][]string
+	}
+
+	// htmlServices - The Services table, with named fields so the template does not depend on the column order
+	htmlServices struct {
+		Headers []string
+		Rows    []htmlServiceRow
+	}
+
+	htmlServiceRow struct {
+		Subscription  string
+		ResourceGroup string
+		Location      string
+		Type          string
+		ServiceName   string
+		Broken        bool
+		Category      string
+		Subcategory   string
+		Severity      string
+		Description   string
+		Result        string
+		Learn         string
+		Suppressed    bool
+		Justification string
+	}
+
+	htmlReport struct {
+		Title           string
+		Logo            string
+		Overview        htmlTable
+		CategorySummary htmlTable
+		SeveritySummary htmlTable
+		Recommendations htmlTable
+		Services        htmlServices
+		Categories      []string
+		Severities      []string
+		Defender        *htmlTable
+		Advisor         *htmlTable
+		Costs           *htmlTable
+		CostsPeriod     string
+		Changes         *htmlTable
+		Errors          *htmlTable
+		PartialReason   string
+	}
+)
+
+// CreateHtmlReport - Writes the report data as a single, self-contained HTML file
+func CreateHtmlReport(data ReportData) {
+	filename := fmt.Sprintf("%s.html", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	tmpl, err := template.New("report.html").Funcs(template.FuncMap{
+		"isLink": func(s string) bool {
+			return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+		},
+	}).Parse(string(embeded.GetTemplates("report.html")))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse HTML template")
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create HTML file")
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, newHtmlReport(data)); err != nil {
+		log.Fatal().Err(err).Msg("Failed to render HTML report")
+	}
+}
+
+func newHtmlReport(data ReportData) htmlReport {
+	report := htmlReport{
+		Title: filepath.Base(data.OutputFileName),
+		Logo:  base64.StdEncoding.EncodeToString(embeded.GetTemplates("microsoft.png")),
+	}
+
+	if len(data.MainData) > 0 {
+		report.Overview = toHtmlTable(overviewTable(data))
+		report.Recommendations = toHtmlTable(recommendationsTable(data))
+		report.Services = servicesHtmlTable(data)
+	}
+
+	categories := map[string]int{}
+	severities := map[string]int{}
+	for _, d := range data.MainData {
+		for _, r := range d.Rules {
+			if _, ok := categories[r.Category]; !ok {
+				categories[r.Category] = 0
+			}
+			if _, ok := severities[r.Severity]; !ok {
+				severities[r.Severity] = 0
+			}
+			if r.IsBroken {
+				categories[r.Category]++
+				severities[r.Severity]++
+			}
+		}
+	}
+	report.Categories, report.CategorySummary = summaryTable("Category", categories, sortedKeys(categories))
+	report.Severities, report.SeveritySummary = summaryTable("Severity", severities, sortedSeverities(severities))
+
+	if len(data.DefenderData) > 0 {
+		t := toHtmlTable(defenderTable(data))
+		report.Defender = &t
+	}
+
+	if len(data.AdvisorData) > 0 {
+		t := toHtmlTable(advisorTable(data))
+		report.Advisor = &t
+	}
+
+	if data.CostData != nil && len(data.CostData.Items) > 0 {
+		t := toHtmlTable(costsTable(data))
+		report.Costs = &t
+		report.CostsPeriod = fmt.Sprintf("Costs from %s to %s", data.CostData.From.Format("2006-01-02"), data.CostData.To.Format("2006-01-02"))
+	}
+
+	if len(data.ChangesData) > 0 {
+		t := toHtmlTable(changesTable(data))
+		report.Changes = &t
+	}
+
+	if data.hasErrors() {
+		t := toHtmlTable(errorsTable(data))
+		report.Errors = &t
+	}
+	report.PartialReason = data.PartialReason
+
+	return report
+}
+
+func toHtmlTable(headers []string, rows [][]string) htmlTable {
+	return htmlTable{
+		Headers: headers,
+		Rows:    rows,
+	}
+}
+
+func servicesHtmlTable(data ReportData) htmlServices {
+	headers, _ := servicesTable(data)
+	t := htmlServices{
+		Headers: headers,
+		Rows:    []htmlServiceRow{},
+	}
+	for _, sr := range serviceRules(data) {
+		d, r := sr.Service, sr.Rule
+		t.Rows = append(t.Rows, htmlServiceRow{
+			Subscription:  scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask),
+			ResourceGroup: d.ResourceGroup,
+			Location:      scanners.ParseLocation(d.Location),
+			Type:          d.Type,
+			ServiceName:   d.ServiceName,
+			Broken:        r.IsBroken,
+			Category:      r.Category,
+			Subcategory:   r.Subcategory,
+			Severity:      r.Severity,
+			Description:   r.Description,
+			Result:        r.Result,
+			Learn:         r.Learn,
+			Suppressed:    r.IsSuppressed,
+			Justification: r.Justification,
+		})
+	}
+	return t
+}
+
+func summaryTable(name string, counts map[string]int, keys []string) ([]string, htmlTable) {
+	t := htmlTable{
+		Headers: []string{name, "Broken Rules"},
+		Rows:    [][]string{},
+	}
+	for _, k := range keys {
+		t.Rows = append(t.Rows, []string{k, strconv.Itoa(counts[k])})
+	}
+	return keys, t
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedSeverities - Returns the severities from High to Low
+func sortedSeverities(m map[string]int) []string {
+	order := map[string]int{
+		scanners.SeverityHigh:   0,
+		scanners.SeverityMedium: 1,
+		scanners.SeverityLow:    2,
+	}
+	keys := sortedKeys(m)
+	sort.SliceStable(keys, func(i, j int) bool {
+		oi, ok := order[keys[i]]
+		if !ok {
+			oi = len(order)
+		}
+		oj, ok := order[keys[j]]
+		if !ok {
+			oj = len(order)
+		}
+		return oi < oj
+	})
+	return keys
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateHtmlReport(t *testing.T) {
+	data := testReportData()
+	data.OutputFileName = filepath.Join(t.TempDir(), "azqr_report")
+
+	CreateHtmlReport(data)
+
+	content, err := os.ReadFile(data.OutputFileName + ".html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(content)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"broken row", `<tr data-broken="true" data-category="Reliability" data-severity="Medium">`},
+		{"broken cell", `<td class="broken-true">true</td>`},
+		{"not broken row", `<tr data-broken="false" data-category="Reliability" data-severity="High">`},
+		{"suppressed row", `<tr data-broken="suppressed" data-category="Operational Excellence" data-severity="Low">`},
+		{"justification", `<td>Tagged by policy</td>`},
+		{"learn link", `<a href="https://learn.microsoft.com/kv-001" target="_blank" rel="noopener">Learn</a>`},
+		{"subscription", `<td>` + testSubscriptionID + `</td>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("CreateHtmlReport() does not contain %s", tt.want)
+			}
+		})
+	}
+}
+
+func TestServicesHtmlTable_Mask(t *testing.T) {
+	data := testReportData()
+	data.Mask = true
+
+	services := servicesHtmlTable(data)
+	if len(services.Rows) != 3 {
+		t.Fatalf("servicesHtmlTable() rows = %v, want 3 rows", services.Rows)
+	}
+	for _, r := range services.Rows {
+		if r.Subscription != testMaskedSubscriptionID {
+			t.Errorf("servicesHtmlTable() subscription = %s, want %s", r.Subscription, testMaskedSubscriptionID)
+		}
+	}
+	if !services.Rows[0].Broken || services.Rows[0].Learn != "https://learn.microsoft.com/kv-001" {
+		t.Errorf("servicesHtmlTable() first row = %v, want the broken kv-001 rule", services.Rows[0])
+	}
+}
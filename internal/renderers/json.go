@@ -0,0 +1,356 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// JsonSchemaVersion - Version of the JSON report document. Bump it whenever
+// a field is renamed or removed so downstream tooling can detect the change.
+const JsonSchemaVersion = "1.0"
+
+type (
+	// JsonReport - Root of the JSON report document
+	JsonReport struct {
+		SchemaVersion string               `json:"schemaVersion"`
+		GeneratedAt   time.Time            `json:"generatedAt"`
+		Services      []JsonServiceResult  `json:"services"`
+		Defender      []JsonDefenderResult `json:"defender"`
+		Advisor       []JsonAdvisorResult  `json:"advisor"`
+		Costs         *JsonCostResult      `json:"costs,omitempty"`
+		Changes       []JsonChangeResult   `json:"changes,omitempty"`
+		Errors        []JsonScanError      `json:"errors,omitempty"`
+		Partial       bool                 `json:"partial,omitempty"`
+		PartialReason string               `json:"partialReason,omitempty"`
+	}
+
+	// JsonServiceResult - JSON representation of an AzureServiceResult
+	JsonServiceResult struct {
+		SubscriptionID string           `json:"subscriptionId"`
+		ResourceGroup  string           `json:"resourceGroup"`
+		Location       string           `json:"location"`
+		Type           string           `json:"type"`
+		Name           string           `json:"name"`
+		ID             string           `json:"id"`
+		Rules          []JsonRuleResult `json:"rules"`
+	}
+
+	// JsonRuleResult - JSON representation of an AzureRuleResult
+	JsonRuleResult struct {
+		Id            string `json:"id"`
+		Category      string `json:"category"`
+		Subcategory   string `json:"subcategory"`
+		Description   string `json:"description"`
+		Severity      string `json:"severity"`
+		Learn         string `json:"learn"`
+		Result        string `json:"result"`
+		Broken        bool   `json:"broken"`
+		Suppressed    bool   `json:"suppressed"`
+		Justification string `json:"justification,omitempty"`
+	}
+
+	// JsonDefenderResult - JSON representation of a DefenderResult
+	JsonDefenderResult struct {
+		SubscriptionID string `json:"subscriptionId"`
+		Name           string `json:"name"`
+		Tier           string `json:"tier"`
+		Deprecated     bool   `json:"deprecated"`
+	}
+
+	// JsonAdvisorResult - JSON representation of an AdvisorResult
+	JsonAdvisorResult struct {
+		SubscriptionID    string `json:"subscriptionId"`
+		Name              string `json:"name"`
+		Type              string `json:"type"`
+		Category          string `json:"category"`
+		Description       string `json:"description"`
+		PotentialBenefits string `json:"potentialBenefits"`
+		Risk              string `json:"risk"`
+		Learn             string `json:"learn"`
+	}
+
+	// JsonCostResult - JSON representation of a CostResult
+	JsonCostResult struct {
+		From  time.Time            `json:"from"`
+		To    time.Time            `json:"to"`
+		Items []JsonCostResultItem `json:"items"`
+	}
+
+	// JsonCostResultItem - JSON representation of a CostResultItem
+	JsonCostResultItem struct {
+		SubscriptionID string `json:"subscriptionId"`
+		ServiceName    string `json:"serviceName"`
+		Value          string `json:"value"`
+		Currency       string `json:"currency"`
+	}
+
+	// JsonChangeResult - JSON representation of a ChangeResult
+	JsonChangeResult struct {
+		Change         string `json:"change"`
+		SubscriptionID string `json:"subscriptionId"`
+		ResourceGroup  string `json:"resourceGroup"`
+		Type           string `json:"type"`
+		Name           string `json:"name"`
+		ID             string `json:"id"`
+		RuleId         string `json:"ruleId,omitempty"`
+		Category       string `json:"category,omitempty"`
+		Severity       string `json:"severity,omitempty"`
+		Description    string `json:"description,omitempty"`
+		Result         string `json:"result,omitempty"`
+	}
+
+	// JsonScanError - JSON representation of a ScanError
+	JsonScanError struct {
+		SubscriptionID string `json:"subscriptionId"`
+		ResourceGroup  string `json:"resourceGroup,omitempty"`
+		Scanner        string `json:"scanner"`
+		Error          string `json:"error"`
+	}
+)
+
+// CreateJsonReport - Writes the report data as a JSON document
+func CreateJsonReport(data ReportData) {
+	filename := fmt.Sprintf("%s.json", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	report := NewJsonReport(data)
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to marshal JSON report")
+	}
+
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		log.Fatal().Err(err).Msg("Failed to save JSON file")
+	}
+}
+
+// NewJsonReport - Builds the JSON report document from the report data
+func NewJsonReport(data ReportData) JsonReport {
+	report := JsonReport{
+		SchemaVersion: JsonSchemaVersion,
+		GeneratedAt:   time.Now().UTC(),
+		Services:      []JsonServiceResult{},
+		Defender:      []JsonDefenderResult{},
+		Advisor:       []JsonAdvisorResult{},
+	}
+
+	for _, d := range data.MainData {
+		report.Services = append(report.Services, JsonServiceResult{
+			SubscriptionID: scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask),
+			ResourceGroup:  d.ResourceGroup,
+			Location:       scanners.ParseLocation(d.Location),
+			Type:           d.Type,
+			Name:           d.ServiceName,
+			ID:             scanners.MaskResourceID(d.ID, d.SubscriptionID, data.Mask),
+			Rules:          toJsonRuleResults(d.Rules),
+		})
+	}
+
+	for _, d := range data.DefenderData {
+		report.Defender = append(report.Defender, JsonDefenderResult{
+			SubscriptionID: scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask),
+			Name:           d.Name,
+			Tier:           d.Tier,
+			Deprecated:     d.Deprecated,
+		})
+	}
+
+	for _, a := range data.AdvisorData {
+		report.Advisor = append(report.Advisor, JsonAdvisorResult{
+			SubscriptionID:    scanners.MaskSubscriptionID(a.SubscriptionID, data.Mask),
+			Name:              a.Name,
+			Type:              a.Type,
+			Category:          a.Category,
+			Description:       a.Description,
+			PotentialBenefits: a.PotentialBenefits,
+			Risk:              a.Risk,
+			Learn:             a.LearnMoreLink,
+		})
+	}
+
+	if data.CostData != nil && len(data.CostData.Items) > 0 {
+		costs := &JsonCostResult{
+			From:  data.CostData.From,
+			To:    data.CostData.To,
+			Items: []JsonCostResultItem{},
+		}
+		for _, c := range data.CostData.Items {
+			costs.Items = append(costs.Items, JsonCostResultItem{
+				SubscriptionID: scanners.MaskSubscriptionID(c.SubscriptionID, data.Mask),
+				ServiceName:    c.ServiceName,
+				Value:          c.Value,
+				Currency:       c.Currency,
+			})
+		}
+		report.Costs = costs
+	}
+
+	for _, c := range data.ChangesData {
+		report.Changes = append(report.Changes, JsonChangeResult{
+			Change:         c.Change,
+			SubscriptionID: scanners.MaskSubscriptionID(c.SubscriptionID, data.Mask),
+			ResourceGroup:  c.ResourceGroup,
+			Type:           c.Type,
+			Name:           c.ServiceName,
+			ID:             scanners.MaskResourceID(c.ID, c.SubscriptionID, data.Mask),
+			RuleId:         c.RuleId,
+			Category:       c.Category,
+			Severity:       c.Severity,
+			Description:    c.Description,
+			Result:         c.Result,
+		})
+	}
+
+	for _, e := range data.ErrorsData {
+		report.Errors = append(report.Errors, JsonScanError{
+			SubscriptionID: scanners.MaskSubscriptionID(e.SubscriptionID, data.Mask),
+			ResourceGroup:  e.ResourceGroup,
+			Scanner:        e.Scanner,
+			Error:          scanners.MaskResourceID(e.Error, e.SubscriptionID, data.Mask),
+		})
+	}
+
+	report.Partial = data.PartialReason != ""
+	report.PartialReason = data.PartialReason
+
+	return report
+}
+
+// toJsonRuleResults - Returns the rule results sorted by Id so the output is stable between runs
+func toJsonRuleResults(rules map[string]scanners.AzureRuleResult) []JsonRuleResult {
+	results := make([]JsonRuleResult, 0, len(rules))
+	for _, r := range rules {
+		results = append(results, JsonRuleResult{
+			Id:            r.Id,
+			Category:      r.Category,
+			Subcategory:   r.Subcategory,
+			Description:   r.Description,
+			Severity:      r.Severity,
+			Learn:         r.Learn,
+			Result:        r.Result,
+			Broken:        r.IsBroken,
+			Suppressed:    r.IsSuppressed,
+			Justification: r.Justification,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Id < results[j].Id
+	})
+
+	return results
+}
+
+// LoadJsonReport - Reads a JSON report written by CreateJsonReport
+func LoadJsonReport(path string) (*JsonReport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &JsonReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON report %s: %w", path, err)
+	}
+	if report.SchemaVersion == "" {
+		return nil, fmt.Errorf("%s is not an azqr JSON report", path)
+	}
+	return report, nil
+}
+
+// ToReportData - Rebuilds the report data of a JSON report. Subscription ids are kept
+// as they were written, so the report data is not masked again.
+func (r JsonReport) ToReportData() ReportData {
+	data := ReportData{
+		MainData:     []scanners.AzureServiceResult{},
+		DefenderData: []scanners.DefenderResult{},
+		AdvisorData:  []scanners.AdvisorResult{},
+	}
+
+	for _, s := range r.Services {
+		rules := map[string]scanners.AzureRuleResult{}
+		for _, rr := range s.Rules {
+			rules[rr.Id] = scanners.AzureRuleResult{
+				Id:            rr.Id,
+				Category:      rr.Category,
+				Subcategory:   rr.Subcategory,
+				Description:   rr.Description,
+				Severity:      rr.Severity,
+				Learn:         rr.Learn,
+				Result:        rr.Result,
+				IsBroken:      rr.Broken,
+				IsSuppressed:  rr.Suppressed,
+				Justification: rr.Justification,
+			}
+		}
+		data.MainData = append(data.MainData, scanners.AzureServiceResult{
+			SubscriptionID: s.SubscriptionID,
+			ResourceGroup:  s.ResourceGroup,
+			Location:       s.Location,
+			Type:           s.Type,
+			ServiceName:    s.Name,
+			ID:             s.ID,
+			Rules:          rules,
+		})
+	}
+
+	for _, d := range r.Defender {
+		data.DefenderData = append(data.DefenderData, scanners.DefenderResult{
+			SubscriptionID: d.SubscriptionID,
+			Name:           d.Name,
+			Tier:           d.Tier,
+			Deprecated:     d.Deprecated,
+		})
+	}
+
+	for _, a := range r.Advisor {
+		data.AdvisorData = append(data.AdvisorData, scanners.AdvisorResult{
+			SubscriptionID:    a.SubscriptionID,
+			Name:              a.Name,
+			Type:              a.Type,
+			Category:          a.Category,
+			Description:       a.Description,
+			PotentialBenefits: a.PotentialBenefits,
+			Risk:              a.Risk,
+			LearnMoreLink:     a.Learn,
+		})
+	}
+
+	if r.Costs != nil {
+		data.CostData = &scanners.CostResult{
+			From:  r.Costs.From,
+			To:    r.Costs.To,
+			Items: []*scanners.CostResultItem{},
+		}
+		for _, c := range r.Costs.Items {
+			data.CostData.Items = append(data.CostData.Items, &scanners.CostResultItem{
+				SubscriptionID: c.SubscriptionID,
+				ServiceName:    c.ServiceName,
+				Value:          c.Value,
+				Currency:       c.Currency,
+			})
+		}
+	}
+
+	for _, e := range r.Errors {
+		data.ErrorsData = append(data.ErrorsData, scanners.ScanError{
+			SubscriptionID: e.SubscriptionID,
+			ResourceGroup:  e.ResourceGroup,
+			Scanner:        e.Scanner,
+			Error:          e.Error,
+		})
+	}
+	data.PartialReason = r.PartialReason
+
+	return data
+}
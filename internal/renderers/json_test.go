@@ -0,0 +1,206 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+const (
+	testSubscriptionID       = "00000000-0000-0000-0000-000000000001"
+	testMaskedSubscriptionID = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001"
+	testResourceID           = "/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-1"
+)
+
+// testReportData - Returns report data with one resource, with a broken, a passed and a suppressed rule.
+// Suppressed rules are not broken, as applied by the exclusions.
+func testReportData() ReportData {
+	return ReportData{
+		OutputFileName: "test",
+		MainData: []scanners.AzureServiceResult{
+			{
+				SubscriptionID: testSubscriptionID,
+				ResourceGroup:  "rg1",
+				Location:       "westeurope",
+				Type:           "Microsoft.KeyVault/vaults",
+				ServiceName:    "kv-1",
+				ID:             testResourceID,
+				Rules: map[string]scanners.AzureRuleResult{
+					"kv-001": {
+						Id:          "kv-001",
+						Category:    scanners.RulesCategoryReliability,
+						Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+						Description: "Key Vault should have diagnostic settings enabled",
+						Severity:    scanners.SeverityMedium,
+						Learn:       "https://learn.microsoft.com/kv-001",
+						IsBroken:    true,
+					},
+					"kv-002": {
+						Id:          "kv-002",
+						Category:    scanners.RulesCategoryReliability,
+						Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+						Description: "Key Vault should have a SLA",
+						Severity:    scanners.SeverityHigh,
+						Learn:       "https://learn.microsoft.com/kv-002",
+						Result:      "99.99%",
+					},
+					"kv-003": {
+						Id:            "kv-003",
+						Category:      scanners.RulesCategoryOperationalExcellence,
+						Subcategory:   scanners.RulesSubcategoryOperationalExcellenceTags,
+						Description:   "Key Vault should have tags",
+						Severity:      scanners.SeverityLow,
+						Learn:         "https://learn.microsoft.com/kv-003",
+						IsSuppressed:  true,
+						Justification: "Tagged by policy",
+					},
+				},
+			},
+		},
+		DefenderData: []scanners.DefenderResult{
+			{SubscriptionID: testSubscriptionID, Name: "KeyVaults", Tier: "Standard"},
+		},
+		AdvisorData: []scanners.AdvisorResult{
+			{
+				SubscriptionID:    testSubscriptionID,
+				Name:              "kv-1",
+				Type:              "Microsoft.KeyVault/vaults",
+				Category:          "HighAvailability",
+				Description:       "Enable soft delete",
+				PotentialBenefits: "Recover deleted secrets",
+				Risk:              "Warning",
+				LearnMoreLink:     "https://learn.microsoft.com/advisor",
+			},
+		},
+		CostData: &scanners.CostResult{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Items: []*scanners.CostResultItem{
+				{SubscriptionID: testSubscriptionID, ServiceName: "Key Vault", Value: "1.23", Currency: "EUR"},
+			},
+		},
+		ErrorsData: []scanners.ScanError{
+			{SubscriptionID: testSubscriptionID, ResourceGroup: "rg2", Scanner: "st", Error: "403 on " + testResourceID},
+		},
+	}
+}
+
+func TestJsonReport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		mask          bool
+		partialReason string
+	}{
+		{"unmasked", false, ""},
+		{"partial", false, "The scan timed out after 30m0s"},
+		{"masked", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testReportData()
+			data.Mask = tt.mask
+			data.PartialReason = tt.partialReason
+
+			content, err := json.Marshal(NewJsonReport(data))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			report := JsonReport{}
+			if err := json.Unmarshal(content, &report); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			if report.SchemaVersion != JsonSchemaVersion {
+				t.Errorf("JsonReport.SchemaVersion = %s, want %s", report.SchemaVersion, JsonSchemaVersion)
+			}
+			if report.Partial != (tt.partialReason != "") {
+				t.Errorf("JsonReport.Partial = %t, want %t", report.Partial, tt.partialReason != "")
+			}
+			if got := report.Services[0].Rules; len(got) != 3 || got[0].Id != "kv-001" || got[1].Id != "kv-002" || got[2].Id != "kv-003" {
+				t.Errorf("JsonServiceResult.Rules = %v, want kv-001, kv-002 and kv-003 in order", got)
+			}
+
+			got := report.ToReportData()
+			want := testReportData()
+			want.PartialReason = tt.partialReason
+			if tt.mask {
+				maskReportData(&want)
+			}
+
+			if !reflect.DeepEqual(got.MainData, want.MainData) {
+				t.Errorf("ToReportData().MainData = %v, want %v", got.MainData, want.MainData)
+			}
+			if !reflect.DeepEqual(got.DefenderData, want.DefenderData) {
+				t.Errorf("ToReportData().DefenderData = %v, want %v", got.DefenderData, want.DefenderData)
+			}
+			if !reflect.DeepEqual(got.AdvisorData, want.AdvisorData) {
+				t.Errorf("ToReportData().AdvisorData = %v, want %v", got.AdvisorData, want.AdvisorData)
+			}
+			if !reflect.DeepEqual(got.CostData, want.CostData) {
+				t.Errorf("ToReportData().CostData = %v, want %v", got.CostData, want.CostData)
+			}
+			if !reflect.DeepEqual(got.ErrorsData, want.ErrorsData) {
+				t.Errorf("ToReportData().ErrorsData = %v, want %v", got.ErrorsData, want.ErrorsData)
+			}
+			if got.PartialReason != want.PartialReason {
+				t.Errorf("ToReportData().PartialReason = %s, want %s", got.PartialReason, want.PartialReason)
+			}
+		})
+	}
+}
+
+func TestLoadJsonReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"report", `{"schemaVersion": "1.0", "services": [{"name": "kv-1"}]}`, false},
+		{"not a report", `{"value": []}`, true},
+		{"invalid JSON", `{"schemaVersion": `, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "report.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			report, err := LoadJsonReport(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadJsonReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && report.Services[0].Name != "kv-1" {
+				t.Errorf("LoadJsonReport() = %v, want service kv-1", report)
+			}
+		})
+	}
+}
+
+// maskReportData - Masks the subscription ids of the report data the way NewJsonReport does
+func maskReportData(data *ReportData) {
+	for i := range data.MainData {
+		data.MainData[i].ID = scanners.MaskResourceID(data.MainData[i].ID, data.MainData[i].SubscriptionID, true)
+		data.MainData[i].SubscriptionID = testMaskedSubscriptionID
+	}
+	for i := range data.DefenderData {
+		data.DefenderData[i].SubscriptionID = testMaskedSubscriptionID
+	}
+	for i := range data.AdvisorData {
+		data.AdvisorData[i].SubscriptionID = testMaskedSubscriptionID
+	}
+	for _, c := range data.CostData.Items {
+		c.SubscriptionID = testMaskedSubscriptionID
+	}
+	for i := range data.ErrorsData {
+		data.ErrorsData[i].Error = scanners.MaskResourceID(data.ErrorsData[i].Error, data.ErrorsData[i].SubscriptionID, true)
+		data.ErrorsData[i].SubscriptionID = testMaskedSubscriptionID
+	}
+}
@@ -17,12 +17,7 @@ func renderOverview(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to rename sheet")
 		}
 
-		headers := data.MainData[0].GetHeaders()
-
-		rows := [][]string{}
-		for _, r := range data.MainData {
-			rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
-		}
+		headers, rows := overviewTable(data)
 
 		createFirstRow(f, "Overview", headers)
 
@@ -44,3 +39,13 @@ func renderOverview(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Overview. No data to render")
 	}
 }
+
+func overviewTable(data ReportData) ([]string, [][]string) {
+	headers := data.MainData[0].GetHeaders()
+
+	rows := [][]string{}
+	for _, r := range data.MainData {
+		rows = append(mapToRow(headers, r.ToMap(data.Mask)), rows...)
+	}
+	return headers, rows
+}
@@ -17,27 +17,7 @@ func renderRecommendations(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to create Recommendations sheet")
 		}
 
-		renderedRules := map[string]bool{}
-
-		headers := []string{"Id", "Category", "Subcategory", "Description", "Severity", "Learn"}
-		rows := [][]string{}
-		for _, result := range data.MainData {
-			for _, rr := range result.Rules {
-				_, exists := renderedRules[rr.Id]
-				if !exists && rr.IsBroken {
-					rulesToRender := map[string]string{
-						"Id":          rr.Id,
-						"Category":    rr.Category,
-						"Subcategory": rr.Subcategory,
-						"Description": rr.Description,
-						"Severity":    rr.Severity,
-						"Learn":       rr.Learn,
-					}
-					renderedRules[rr.Id] = true
-					rows = append(rows, mapToRow(headers, rulesToRender)...)
-				}
-			}
-		}
+		headers, rows := recommendationsTable(data)
 
 		createFirstRow(f, "Recommendations", headers)
 
@@ -61,3 +41,28 @@ func renderRecommendations(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Recommendations. No data to render")
 	}
 }
+
+func recommendationsTable(data ReportData) ([]string, [][]string) {
+	renderedRules := map[string]bool{}
+
+	headers := []string{"Id", "Category", "Subcategory", "Description", "Severity", "Learn"}
+	rows := [][]string{}
+	for _, result := range data.MainData {
+		for _, rr := range result.Rules {
+			_, exists := renderedRules[rr.Id]
+			if !exists && rr.IsBroken {
+				rulesToRender := map[string]string{
+					"Id":          rr.Id,
+					"Category":    rr.Category,
+					"Subcategory": rr.Subcategory,
+					"Description": rr.Description,
+					"Severity":    rr.Severity,
+					"Learn":       rr.Learn,
+				}
+				renderedRules[rr.Id] = true
+				rows = append(rows, mapToRow(headers, rulesToRender)...)
+			}
+		}
+	}
+	return headers, rows
+}
@@ -15,4 +15,13 @@ type ReportData struct {
 	DefenderData       []scanners.DefenderResult
 	AdvisorData        []scanners.AdvisorResult
 	CostData           *scanners.CostResult
+	ChangesData        []ChangeResult
+	ErrorsData         []scanners.ScanError
+	// PartialReason - Why the scan stopped before completing. Empty when the report is complete.
+	PartialReason string
+}
+
+// hasErrors - Returns true if the report has scan errors or is partial
+func (d ReportData) hasErrors() bool {
+	return len(d.ErrorsData) > 0 || d.PartialReason != ""
 }
@@ -0,0 +1,240 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool        sarifTool         `json:"tool"`
+		Invocations []sarifInvocation `json:"invocations,omitempty"`
+		Results     []sarifResult     `json:"results"`
+	}
+
+	sarifInvocation struct {
+		ExecutionSuccessful        bool                `json:"executionSuccessful"`
+		ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications"`
+	}
+
+	sarifNotification struct {
+		Level   string       `json:"level"`
+		Message sarifMessage `json:"message"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string                     `json:"name"`
+		InformationUri string                     `json:"informationUri"`
+		Rules          []sarifReportingDescriptor `json:"rules"`
+	}
+
+	sarifReportingDescriptor struct {
+		Id                   string              `json:"id"`
+		ShortDescription     sarifMessage        `json:"shortDescription"`
+		HelpUri              string              `json:"helpUri,omitempty"`
+		DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+		Properties           sarifRuleProperties `json:"properties"`
+	}
+
+	sarifConfiguration struct {
+		Level string `json:"level"`
+	}
+
+	sarifRuleProperties struct {
+		Category    string   `json:"category"`
+		Subcategory string   `json:"subcategory"`
+		Severity    string   `json:"severity"`
+		Tags        []string `json:"tags"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleId       string             `json:"ruleId"`
+		RuleIndex    int                `json:"ruleIndex"`
+		Level        string             `json:"level"`
+		Message      sarifMessage       `json:"message"`
+		Locations    []sarifLocation    `json:"locations"`
+		Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	}
+
+	sarifSuppression struct {
+		Kind          string `json:"kind"`
+		Justification string `json:"justification,omitempty"`
+	}
+
+	sarifLocation struct {
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	}
+
+	sarifLogicalLocation struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+// CreateSarifReport - Writes the broken and suppressed rules as a SARIF 2.1.0 log
+func CreateSarifReport(data ReportData) {
+	filename := fmt.Sprintf("%s.sarif", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	content, err := json.MarshalIndent(newSarifLog(data), "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to marshal SARIF report")
+	}
+
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		log.Fatal().Err(err).Msg("Failed to save SARIF file")
+	}
+}
+
+func newSarifLog(data ReportData) sarifLog {
+	descriptors := []sarifReportingDescriptor{}
+	ruleIndex := map[string]int{}
+	results := []sarifResult{}
+
+	for _, d := range data.MainData {
+		keys := make([]string, 0, len(d.Rules))
+		for k := range d.Rules {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			r := d.Rules[k]
+			if !r.IsBroken && !r.IsSuppressed {
+				continue
+			}
+
+			i, ok := ruleIndex[r.Id]
+			if !ok {
+				i = len(descriptors)
+				ruleIndex[r.Id] = i
+				descriptors = append(descriptors, sarifReportingDescriptor{
+					Id:               r.Id,
+					ShortDescription: sarifMessage{Text: r.Description},
+					HelpUri:          r.Learn,
+					DefaultConfiguration: sarifConfiguration{
+						Level: toSarifLevel(r.Severity),
+					},
+					Properties: sarifRuleProperties{
+						Category:    r.Category,
+						Subcategory: r.Subcategory,
+						Severity:    r.Severity,
+						Tags:        []string{r.Category, r.Subcategory},
+					},
+				})
+			}
+
+			message := fmt.Sprintf("%s: %s", d.ServiceName, r.Description)
+			if r.Result != "" {
+				message = fmt.Sprintf("%s (%s)", message, r.Result)
+			}
+
+			var suppressions []sarifSuppression
+			if r.IsSuppressed {
+				suppressions = []sarifSuppression{
+					{
+						Kind:          "external",
+						Justification: r.Justification,
+					},
+				}
+			}
+
+			results = append(results, sarifResult{
+				RuleId:    r.Id,
+				RuleIndex: i,
+				Level:     toSarifLevel(r.Severity),
+				Message:   sarifMessage{Text: message},
+				Locations: []sarifLocation{
+					{
+						LogicalLocations: []sarifLogicalLocation{
+							{
+								Name:               d.ServiceName,
+								FullyQualifiedName: scanners.MaskResourceID(d.ID, d.SubscriptionID, data.Mask),
+								Kind:               "resource",
+							},
+						},
+					},
+				},
+				Suppressions: suppressions,
+			})
+		}
+	}
+
+	// Scan errors are reported as notifications of an unsuccessful invocation
+	var invocations []sarifInvocation
+	if data.hasErrors() {
+		_, rows := errorsTable(data)
+		notifications := []sarifNotification{}
+		for _, row := range rows {
+			notifications = append(notifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{Text: strings.Join(row, " | ")},
+			})
+		}
+		invocations = []sarifInvocation{
+			{
+				ExecutionSuccessful:        false,
+				ToolExecutionNotifications: notifications,
+			},
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "azqr",
+						InformationUri: "https://azure.github.io/azqr",
+						Rules:          descriptors,
+					},
+				},
+				Invocations: invocations,
+				Results:     results,
+			},
+		},
+	}
+}
+
+// toSarifLevel - Maps an azqr rule severity to a SARIF result level
+func toSarifLevel(severity string) string {
+	switch severity {
+	case scanners.SeverityHigh:
+		return "error"
+	case scanners.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
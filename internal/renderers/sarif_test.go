@@ -0,0 +1,142 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestNewSarifLog(t *testing.T) {
+	tests := []struct {
+		name string
+		mask bool
+		want []sarifResult
+	}{
+		{
+			name: "broken and suppressed rules",
+			mask: false,
+			want: []sarifResult{
+				{
+					RuleId:    "kv-001",
+					RuleIndex: 0,
+					Level:     "warning",
+					Message:   sarifMessage{Text: "kv-1: Key Vault should have diagnostic settings enabled"},
+					Locations: testSarifLocations(testResourceID),
+				},
+				{
+					RuleId:    "kv-003",
+					RuleIndex: 1,
+					Level:     "note",
+					Message:   sarifMessage{Text: "kv-1: Key Vault should have tags"},
+					Locations: testSarifLocations(testResourceID),
+					Suppressions: []sarifSuppression{
+						{Kind: "external", Justification: "Tagged by policy"},
+					},
+				},
+			},
+		},
+		{
+			name: "masked",
+			mask: true,
+			want: []sarifResult{
+				{
+					RuleId:    "kv-001",
+					RuleIndex: 0,
+					Level:     "warning",
+					Message:   sarifMessage{Text: "kv-1: Key Vault should have diagnostic settings enabled"},
+					Locations: testSarifLocations(scanners.MaskResourceID(testResourceID, testSubscriptionID, true)),
+				},
+				{
+					RuleId:    "kv-003",
+					RuleIndex: 1,
+					Level:     "note",
+					Message:   sarifMessage{Text: "kv-1: Key Vault should have tags"},
+					Locations: testSarifLocations(scanners.MaskResourceID(testResourceID, testSubscriptionID, true)),
+					Suppressions: []sarifSuppression{
+						{Kind: "external", Justification: "Tagged by policy"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testReportData()
+			data.Mask = tt.mask
+			data.ErrorsData = nil
+
+			log := newSarifLog(data)
+			if log.Version != sarifVersion || len(log.Runs) != 1 {
+				t.Fatalf("newSarifLog() = version %s with %d runs, want %s with 1 run", log.Version, len(log.Runs), sarifVersion)
+			}
+			run := log.Runs[0]
+
+			if !reflect.DeepEqual(run.Results, tt.want) {
+				t.Errorf("newSarifLog() results = %v, want %v", run.Results, tt.want)
+			}
+
+			// Passed rules have no result and no descriptor
+			rules := run.Tool.Driver.Rules
+			if len(rules) != 2 || rules[0].Id != "kv-001" || rules[1].Id != "kv-003" {
+				t.Fatalf("newSarifLog() rules = %v, want kv-001 and kv-003", rules)
+			}
+			if rules[0].DefaultConfiguration.Level != "warning" || rules[0].HelpUri != "https://learn.microsoft.com/kv-001" {
+				t.Errorf("newSarifLog() rule kv-001 = %v, want level warning and its learn url", rules[0])
+			}
+			if run.Invocations != nil {
+				t.Errorf("newSarifLog() invocations = %v, want none without errors", run.Invocations)
+			}
+		})
+	}
+}
+
+func TestNewSarifLog_Errors(t *testing.T) {
+	data := testReportData()
+	data.PartialReason = "The scan was canceled"
+
+	invocations := newSarifLog(data).Runs[0].Invocations
+	if len(invocations) != 1 || invocations[0].ExecutionSuccessful {
+		t.Fatalf("newSarifLog() invocations = %v, want one unsuccessful invocation", invocations)
+	}
+
+	want := []sarifNotification{
+		{Level: "error", Message: sarifMessage{Text: " |  | azqr | The scan was canceled. The report is partial"}},
+		{Level: "error", Message: sarifMessage{Text: testSubscriptionID + " | rg2 | st | 403 on " + testResourceID}},
+	}
+	if got := invocations[0].ToolExecutionNotifications; !reflect.DeepEqual(got, want) {
+		t.Errorf("newSarifLog() notifications = %v, want %v", got, want)
+	}
+}
+
+func TestToSarifLevel(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{scanners.SeverityHigh, "error"},
+		{scanners.SeverityMedium, "warning"},
+		{scanners.SeverityLow, "note"},
+		{"", "note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			if got := toSarifLevel(tt.severity); got != tt.want {
+				t.Errorf("toSarifLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testSarifLocations(fullyQualifiedName string) []sarifLocation {
+	return []sarifLocation{
+		{
+			LogicalLocations: []sarifLogicalLocation{
+				{Name: "kv-1", FullyQualifiedName: fullyQualifiedName, Kind: "resource"},
+			},
+		},
+	}
+}
@@ -19,38 +19,10 @@ func renderServices(f *excelize.File, data ReportData) {
 			log.Fatal().Err(err).Msg("Failed to create Services sheet")
 		}
 
-		headers := []string{"Subscription", "Resource Group", "Location", "Type", "Service Name", "Broken", "Category", "Subcategory", "Severity", "Description", "Result", "Learn"}
-
-		rbroken := [][]string{}
-		rok := [][]string{}
-		for _, d := range data.MainData {
-			for _, r := range d.Rules {
-				row := []string{
-					scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask),
-					d.ResourceGroup,
-					scanners.ParseLocation(d.Location),
-					d.Type,
-					d.ServiceName,
-					fmt.Sprintf("%t", r.IsBroken),
-					r.Category,
-					r.Subcategory,
-					r.Severity,
-					r.Description,
-					r.Result,
-					r.Learn,
-				}
-				if r.IsBroken {
-					rbroken = append([][]string{row}, rbroken...)
-				} else {
-					rok = append([][]string{row}, rok...)
-				}
-			}
-		}
+		headers, rows := servicesTable(data)
 
 		createFirstRow(f, "Services", headers)
 
-		rows := append(rbroken, rok...)
-
 		currentRow := 4
 		for _, row := range rows {
 			currentRow += 1
@@ -70,3 +42,54 @@ func renderServices(f *excelize.File, data ReportData) {
 		log.Info().Msg("Skipping Services. No data to render")
 	}
 }
+
+// serviceRule - A rule result of a resource, as listed in the Services sheet
+type serviceRule struct {
+	Service scanners.AzureServiceResult
+	Rule    scanners.AzureRuleResult
+}
+
+// serviceRules - Returns the rule results of every resource, broken rules first
+func serviceRules(data ReportData) []serviceRule {
+	rbroken := []serviceRule{}
+	rok := []serviceRule{}
+	for _, d := range data.MainData {
+		for _, r := range d.Rules {
+			row := serviceRule{Service: d, Rule: r}
+			if r.IsBroken {
+				rbroken = append([]serviceRule{row}, rbroken...)
+			} else {
+				rok = append([]serviceRule{row}, rok...)
+			}
+		}
+	}
+
+	return append(rbroken, rok...)
+}
+
+func servicesTable(data ReportData) ([]string, [][]string) {
+	headers := []string{"Subscription", "Resource Group", "Location", "Type", "Service Name", "Broken", "Category", "Subcategory", "Severity", "Description", "Result", "Learn", "Suppressed", "Justification"}
+
+	rows := [][]string{}
+	for _, sr := range serviceRules(data) {
+		d, r := sr.Service, sr.Rule
+		rows = append(rows, []string{
+			scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask),
+			d.ResourceGroup,
+			scanners.ParseLocation(d.Location),
+			d.Type,
+			d.ServiceName,
+			fmt.Sprintf("%t", r.IsBroken),
+			r.Category,
+			r.Subcategory,
+			r.Severity,
+			r.Description,
+			r.Result,
+			r.Learn,
+			fmt.Sprintf("%t", r.IsSuppressed),
+			r.Justification,
+		})
+	}
+
+	return headers, rows
+}
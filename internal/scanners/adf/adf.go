@@ -15,6 +15,17 @@ type DataFactoryScanner struct {
 	factoriesClient *armdatafactory.FactoriesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "adf",
+		Description: "Azure Data Factory",
+		ResourceTypes: []string{
+			"Microsoft.DataFactory/factories",
+		},
+		New: func() scanners.IAzureScanner { return &DataFactoryScanner{} },
+	})
+}
+
 // Init - Initializes the FrontDoor Scanner
 func (a *DataFactoryScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,10 +51,11 @@ func (a *DataFactoryScanner) Scan(resourceGroupName string, scanContext *scanner
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			Location:       *g.Location,
 			Type:           *g.Type,
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Rules:          rr,
 		})
 	}
@@ -51,6 +63,24 @@ func (a *DataFactoryScanner) Scan(resourceGroupName string, scanContext *scanner
 }
 
 func (a *DataFactoryScanner) listFactories(resourceGroupName string) ([]*armdatafactory.Factory, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armdatafactory.Factory](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.DataFactory/factories")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.factoriesClient.NewListPager(nil)
+
+		factories := make([]*armdatafactory.Factory, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			factories = append(factories, resp.Value...)
+		}
+		return factories, nil
+	}
+
 	pager := a.factoriesClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	factories := make([]*armdatafactory.Factory, 0)
@@ -37,14 +37,14 @@ func (a AdvisorResult) GetProperties() []string {
 // ToMap - Returns the properties of the AdvisorResult as a map
 func (a AdvisorResult) ToMap(mask bool) map[string]string {
 	return map[string]string{
-		"SubscriptionID":     MaskSubscriptionID(a.SubscriptionID, mask),
-		"Name":               a.Name,
-		"Type":               a.Type,
-		"Category":           a.Category,
-		"Description":        a.Description,
-		"Potential Benefits": a.PotentialBenefits,
-		"Risk":               a.Risk,
-		"Learn":              a.LearnMoreLink,
+		"SubscriptionID":    MaskSubscriptionID(a.SubscriptionID, mask),
+		"Name":              a.Name,
+		"Type":              a.Type,
+		"Category":          a.Category,
+		"Description":       a.Description,
+		"PotentialBenefits": a.PotentialBenefits,
+		"Risk":              a.Risk,
+		"LearnMoreLink":     a.LearnMoreLink,
 	}
 }
 
@@ -15,6 +15,17 @@ type FrontDoorScanner struct {
 	client *armcdn.ProfilesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "afd",
+		Description: "Azure Front Door",
+		ResourceTypes: []string{
+			"Microsoft.Cdn/profiles",
+		},
+		New: func() scanners.IAzureScanner { return &FrontDoorScanner{} },
+	})
+}
+
 // Init - Initializes the FrontDoor Scanner
 func (a *FrontDoorScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,10 +51,11 @@ func (a *FrontDoorScanner) Scan(resourceGroupName string, scanContext *scanners.
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			Location:       *g.Location,
 			Type:           *g.Type,
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Rules:          rr,
 		})
 	}
@@ -51,6 +63,24 @@ func (a *FrontDoorScanner) Scan(resourceGroupName string, scanContext *scanners.
 }
 
 func (a *FrontDoorScanner) list(resourceGroupName string) ([]*armcdn.Profile, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcdn.Profile](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Cdn/profiles")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListPager(nil)
+
+		services := make([]*armcdn.Profile, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, resp.Value...)
+		}
+		return services, nil
+	}
+
 	pager := a.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	services := make([]*armcdn.Profile, 0)
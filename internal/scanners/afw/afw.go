@@ -15,6 +15,17 @@ type FirewallScanner struct {
 	client *armnetwork.AzureFirewallsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "afw",
+		Description: "Azure Firewall",
+		ResourceTypes: []string{
+			"Microsoft.Network/azureFirewalls",
+		},
+		New: func() scanners.IAzureScanner { return &FirewallScanner{} },
+	})
+}
+
 // Init - Initializes the Azure Firewall
 func (a *FirewallScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,10 +51,11 @@ func (a *FirewallScanner) Scan(resourceGroupName string, scanContext *scanners.S
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			Location:       *g.Location,
 			Type:           *g.Type,
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Rules:          rr,
 		})
 	}
@@ -51,6 +63,24 @@ func (a *FirewallScanner) Scan(resourceGroupName string, scanContext *scanners.S
 }
 
 func (a *FirewallScanner) list(resourceGroupName string) ([]*armnetwork.AzureFirewall, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.AzureFirewall](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Network/azureFirewalls")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListAllPager(nil)
+
+		services := make([]*armnetwork.AzureFirewall, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, resp.Value...)
+		}
+		return services, nil
+	}
+
 	pager := a.client.NewListPager(resourceGroupName, nil)
 
 	services := make([]*armnetwork.AzureFirewall, 0)
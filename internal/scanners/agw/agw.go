@@ -15,6 +15,17 @@ type ApplicationGatewayScanner struct {
 	gatewaysClient *armnetwork.ApplicationGatewaysClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "agw",
+		Description: "Azure Application Gateway",
+		ResourceTypes: []string{
+			"Microsoft.Network/applicationGateways",
+		},
+		New: func() scanners.IAzureScanner { return &ApplicationGatewayScanner{} },
+	})
+}
+
 // Init - Initializes the ApplicationGatewayAnalyzer
 func (a *ApplicationGatewayScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,8 +51,9 @@ func (a *ApplicationGatewayScanner) Scan(resourceGroupName string, scanContext *
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Type:           *g.Type,
 			Location:       *g.Location,
 			Rules:          rr,
@@ -51,6 +63,23 @@ func (a *ApplicationGatewayScanner) Scan(resourceGroupName string, scanContext *
 }
 
 func (a *ApplicationGatewayScanner) listGateways(resourceGroupName string) ([]*armnetwork.ApplicationGateway, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.ApplicationGateway](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Network/applicationGateways")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.gatewaysClient.NewListAllPager(nil)
+		results := []*armnetwork.ApplicationGateway{}
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, resp.Value...)
+		}
+		return results, nil
+	}
+
 	pager := a.gatewaysClient.NewListPager(resourceGroupName, nil)
 	results := []*armnetwork.ApplicationGateway{}
 	for pager.More() {
@@ -15,6 +15,17 @@ type AKSScanner struct {
 	clustersClient *armcontainerservice.ManagedClustersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "aks",
+		Description: "Azure Kubernetes Service",
+		ResourceTypes: []string{
+			"Microsoft.ContainerService/managedClusters",
+		},
+		New: func() scanners.IAzureScanner { return &AKSScanner{} },
+	})
+}
+
 // Init - Initializes the AKSScanner
 func (a *AKSScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,10 +52,11 @@ func (a *AKSScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*c.ID),
 			Location:       *c.Location,
 			Type:           *c.Type,
 			ServiceName:    *c.Name,
+			ID:             *c.ID,
 			Rules:          rr,
 		})
 	}
@@ -53,6 +65,24 @@ func (a *AKSScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 }
 
 func (a *AKSScanner) listClusters(resourceGroupName string) ([]*armcontainerservice.ManagedCluster, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcontainerservice.ManagedCluster](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.ContainerService/managedClusters")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.clustersClient.NewListPager(nil)
+
+		clusters := make([]*armcontainerservice.ManagedCluster, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, resp.Value...)
+		}
+		return clusters, nil
+	}
+
 	pager := a.clustersClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	clusters := make([]*armcontainerservice.ManagedCluster, 0)
@@ -15,6 +15,17 @@ type APIManagementScanner struct {
 	serviceClient *armapimanagement.ServiceClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "apim",
+		Description: "Azure API Management",
+		ResourceTypes: []string{
+			"Microsoft.ApiManagement/service",
+		},
+		New: func() scanners.IAzureScanner { return &APIManagementScanner{} },
+	})
+}
+
 // Init - Initializes the APIManagementScanner
 func (a *APIManagementScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,8 +51,9 @@ func (a *APIManagementScanner) Scan(resourceGroupName string, scanContext *scann
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*s.ID),
 			ServiceName:    *s.Name,
+			ID:             *s.ID,
 			Type:           *s.Type,
 			Location:       *s.Location,
 			Rules:          rr,
@@ -51,6 +63,24 @@ func (a *APIManagementScanner) Scan(resourceGroupName string, scanContext *scann
 }
 
 func (a *APIManagementScanner) listServices(resourceGroupName string) ([]*armapimanagement.ServiceResource, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armapimanagement.ServiceResource](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.ApiManagement/service")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.serviceClient.NewListPager(nil)
+
+		services := make([]*armapimanagement.ServiceResource, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, resp.Value...)
+		}
+		return services, nil
+	}
+
 	pager := a.serviceClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	services := make([]*armapimanagement.ServiceResource, 0)
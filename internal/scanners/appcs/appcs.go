@@ -15,6 +15,17 @@ type AppConfigurationScanner struct {
 	client *armappconfiguration.ConfigurationStoresClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "appcs",
+		Description: "Azure App Configuration",
+		ResourceTypes: []string{
+			"Microsoft.AppConfiguration/configurationStores",
+		},
+		New: func() scanners.IAzureScanner { return &AppConfigurationScanner{} },
+	})
+}
+
 // Init - Initializes the AppConfigurationScanner
 func (a *AppConfigurationScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,8 +51,9 @@ func (a *AppConfigurationScanner) Scan(resourceGroupName string, scanContext *sc
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*app.ID),
 			ServiceName:    *app.Name,
+			ID:             *app.ID,
 			Type:           *app.Type,
 			Location:       *app.Location,
 			Rules:          rr,
@@ -51,6 +63,23 @@ func (a *AppConfigurationScanner) Scan(resourceGroupName string, scanContext *sc
 }
 
 func (a *AppConfigurationScanner) list(resourceGroupName string) ([]*armappconfiguration.ConfigurationStore, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armappconfiguration.ConfigurationStore](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.AppConfiguration/configurationStores")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListPager(nil)
+		apps := make([]*armappconfiguration.ConfigurationStore, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			apps = append(apps, resp.Value...)
+		}
+		return apps, nil
+	}
+
 	pager := a.client.NewListByResourceGroupPager(resourceGroupName, nil)
 	apps := make([]*armappconfiguration.ConfigurationStore, 0)
 	for pager.More() {
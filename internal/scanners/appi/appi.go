@@ -15,6 +15,17 @@ type AppInsightsScanner struct {
 	client *armapplicationinsights.ComponentsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "appi",
+		Description: "Azure Application Insights",
+		ResourceTypes: []string{
+			"Microsoft.Insights/components",
+		},
+		New: func() scanners.IAzureScanner { return &AppInsightsScanner{} },
+	})
+}
+
 // Init - Initializes the Application Insights Scanner
 func (a *AppInsightsScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,10 +51,11 @@ func (a *AppInsightsScanner) Scan(resourceGroupName string, scanContext *scanner
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			Location:       *g.Location,
 			Type:           *g.Type,
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Rules:          rr,
 		})
 	}
@@ -51,6 +63,24 @@ func (a *AppInsightsScanner) Scan(resourceGroupName string, scanContext *scanner
 }
 
 func (a *AppInsightsScanner) list(resourceGroupName string) ([]*armapplicationinsights.Component, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armapplicationinsights.Component](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Insights/components")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListPager(nil)
+
+		services := make([]*armapplicationinsights.Component, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, resp.Value...)
+		}
+		return services, nil
+	}
+
 	pager := a.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	services := make([]*armapplicationinsights.Component, 0)
@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// BastionScanner - Scanner for Azure Bastion
+type BastionScanner struct {
+	config *scanners.ScannerConfig
+	client *armnetwork.BastionHostsClient
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "bas",
+		Description: "Azure Bastion",
+		ResourceTypes: []string{
+			"Microsoft.Network/bastionHosts",
+		},
+		New: func() scanners.IAzureScanner { return &BastionScanner{} },
+	})
+}
+
+// Init - Initializes the Azure Bastion Scanner
+func (a *BastionScanner) Init(config *scanners.ScannerConfig) error {
+	a.config = config
+	var err error
+	a.client, err = armnetwork.NewBastionHostsClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Azure Bastion hosts in a Resource Group
+func (a *BastionScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Azure Bastion hosts in Resource Group %s", resourceGroupName)
+
+	bastions, err := a.list(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := a.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, b := range bastions {
+		rr := engine.EvaluateRules(rules, b, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: a.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*b.ID),
+			Location:       *b.Location,
+			Type:           *b.Type,
+			ServiceName:    *b.Name,
+			ID:             *b.ID,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (a *BastionScanner) list(resourceGroupName string) ([]*armnetwork.BastionHost, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.BastionHost](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Network/bastionHosts")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListPager(nil)
+
+		services := make([]*armnetwork.BastionHost, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, resp.Value...)
+		}
+		return services, nil
+	}
+
+	pager := a.client.NewListByResourceGroupPager(resourceGroupName, nil)
+
+	services := make([]*armnetwork.BastionHost, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, resp.Value...)
+	}
+	return services, nil
+}
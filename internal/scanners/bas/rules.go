@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// GetRules - Returns the rules for the BastionScanner
+func (a *BastionScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"bas-001": {
+			Id:          "bas-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "Azure Bastion should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*armnetwork.BastionHost)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/bastion/monitor-bastion",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"bas-002": {
+			Id:          "bas-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+			Description: "Azure Bastion SLA",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				return false, "99.95%"
+			},
+			Url:   "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
+			Field: scanners.OverviewFieldSLA,
+		},
+		"bas-003": {
+			Id:          "bas-003",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySKU,
+			Description: "Azure Bastion should use the Standard SKU",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				b := target.(*armnetwork.BastionHost)
+				// Basic is the default SKU
+				sku := armnetwork.BastionHostSKUNameBasic
+				if b.SKU != nil && b.SKU.Name != nil {
+					sku = *b.SKU.Name
+				}
+				return sku == armnetwork.BastionHostSKUNameBasic, string(sku)
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/bastion/configuration-settings#skus",
+			Field: scanners.OverviewFieldSKU,
+		},
+		"bas-004": {
+			Id:          "bas-004",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "Azure Bastion Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				c := target.(*armnetwork.BastionHost)
+				caf := strings.HasPrefix(*c.Name, "bas")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"bas-005": {
+			Id:          "bas-005",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Azure Bastion should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				c := target.(*armnetwork.BastionHost)
+				return len(c.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+func TestBastionScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "BastionScanner DiagnosticSettings",
+			fields: fields{
+				rule: "bas-001",
+				target: &armnetwork.BastionHost{
+					ID: ref.Of("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner SLA",
+			fields: fields{
+				rule:        "bas-002",
+				target:      &armnetwork.BastionHost{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.95%",
+			},
+		},
+		{
+			name: "BastionScanner SKU Standard",
+			fields: fields{
+				rule: "bas-003",
+				target: &armnetwork.BastionHost{
+					SKU: &armnetwork.SKU{
+						Name: ref.Of(armnetwork.BastionHostSKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Standard",
+			},
+		},
+		{
+			name: "BastionScanner SKU Basic by default",
+			fields: fields{
+				rule:        "bas-003",
+				target:      &armnetwork.BastionHost{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Basic",
+			},
+		},
+		{
+			name: "BastionScanner CAF",
+			fields: fields{
+				rule: "bas-004",
+				target: &armnetwork.BastionHost{
+					Name: ref.Of("bas-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner Tags",
+			fields: fields{
+				rule:        "bas-005",
+				target:      &armnetwork.BastionHost{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &BastionScanner{}
+			rules := s.GetRules()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BastionScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
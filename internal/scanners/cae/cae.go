@@ -15,6 +15,17 @@ type ContainerAppsScanner struct {
 	appsClient *armappcontainers.ManagedEnvironmentsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "cae",
+		Description: "Azure Container Apps",
+		ResourceTypes: []string{
+			"Microsoft.App/managedEnvironments",
+		},
+		New: func() scanners.IAzureScanner { return &ContainerAppsScanner{} },
+	})
+}
+
 // Init - Initializes the ContainerAppsScanner
 func (a *ContainerAppsScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,8 +51,9 @@ func (a *ContainerAppsScanner) Scan(resourceGroupName string, scanContext *scann
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*app.ID),
 			ServiceName:    *app.Name,
+			ID:             *app.ID,
 			Type:           *app.Type,
 			Location:       *app.Location,
 			Rules:          rr,
@@ -51,6 +63,23 @@ func (a *ContainerAppsScanner) Scan(resourceGroupName string, scanContext *scann
 }
 
 func (a *ContainerAppsScanner) listApps(resourceGroupName string) ([]*armappcontainers.ManagedEnvironment, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armappcontainers.ManagedEnvironment](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.App/managedEnvironments")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.appsClient.NewListBySubscriptionPager(nil)
+		apps := make([]*armappcontainers.ManagedEnvironment, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			apps = append(apps, resp.Value...)
+		}
+		return apps, nil
+	}
+
 	pager := a.appsClient.NewListByResourceGroupPager(resourceGroupName, nil)
 	apps := make([]*armappcontainers.ManagedEnvironment, 0)
 	for pager.More() {
@@ -0,0 +1,165 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// CheckpointEntry - Results of a scanned resource group, or of the subscription wide scanners
+	// (Defender, Advisor and Costs) once every resource group of the subscription is scanned
+	CheckpointEntry struct {
+		SubscriptionID string               `json:"subscriptionId"`
+		ResourceGroup  string               `json:"resourceGroup"`
+		Subscription   bool                 `json:"subscription,omitempty"`
+		Results        []AzureServiceResult `json:"results,omitempty"`
+		Errors         []ScanError          `json:"errors,omitempty"`
+		Defender       []DefenderResult     `json:"defender,omitempty"`
+		Advisor        []AdvisorResult      `json:"advisor,omitempty"`
+		Costs          *CostResult          `json:"costs,omitempty"`
+	}
+
+	// Checkpoint - JSON lines file where the results of a scan are appended as each resource group
+	// and subscription completes, so an interrupted scan can be resumed. It is safe for concurrent use.
+	Checkpoint struct {
+		path    string
+		mutex   sync.Mutex
+		file    *os.File
+		entries []CheckpointEntry
+	}
+)
+
+// NewCheckpoint - Creates (or truncates) a checkpoint file
+func NewCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{path: path, file: file}, nil
+}
+
+// LoadCheckpoint - Reads the entries of an existing checkpoint file and opens it to append new
+// entries. A last line left incomplete by a crash is discarded.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := [][]byte{}
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	entries := []CheckpointEntry{}
+	var valid int64
+	for i, line := range lines {
+		entry := CheckpointEntry{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			if i == len(lines)-1 {
+				log.Warn().Msgf("Discarding the incomplete last entry of checkpoint %s", path)
+				break
+			}
+			file.Close()
+			return nil, err
+		}
+		entries = append(entries, entry)
+		valid += int64(len(line))
+	}
+
+	if err := file.Truncate(valid); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Checkpoint{path: path, file: file, entries: entries}, nil
+}
+
+// Path - Returns the path of the checkpoint file
+func (c *Checkpoint) Path() string {
+	return c.path
+}
+
+// Entries - Returns the entries loaded from the checkpoint file
+func (c *Checkpoint) Entries() []CheckpointEntry {
+	return c.entries
+}
+
+// HasResourceGroup - Returns true if the resource group (or the whole subscription) was already scanned
+func (c *Checkpoint) HasResourceGroup(subscriptionID, resourceGroup string) bool {
+	for _, e := range c.entries {
+		if !strings.EqualFold(e.SubscriptionID, subscriptionID) {
+			continue
+		}
+		if e.Subscription || strings.EqualFold(e.ResourceGroup, resourceGroup) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSubscription - Returns true if every resource group and the subscription wide scanners of the
+// subscription were already scanned
+func (c *Checkpoint) HasSubscription(subscriptionID string) bool {
+	for _, e := range c.entries {
+		if e.Subscription && strings.EqualFold(e.SubscriptionID, subscriptionID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add - Appends an entry to the checkpoint file and flushes it to disk
+func (c *Checkpoint) Add(entry CheckpointEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, err := c.file.Write(append(content, '\n')); err != nil {
+		return err
+	}
+	return c.file.Sync()
+}
+
+// Close - Closes the checkpoint file
+func (c *Checkpoint) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.file.Close()
+}
+
+// Remove - Closes and deletes the checkpoint file, once the report is complete
+func (c *Checkpoint) Remove() error {
+	if err := c.Close(); err != nil {
+		return err
+	}
+	return os.Remove(c.path)
+}
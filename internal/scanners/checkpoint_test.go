@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpoint_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scan.checkpoint.jsonl")
+
+	checkpoint, err := NewCheckpoint(path)
+	if err != nil {
+		t.Fatalf("NewCheckpoint() error = %v", err)
+	}
+	entries := []CheckpointEntry{
+		{SubscriptionID: "sub1", ResourceGroup: "rg1", Results: []AzureServiceResult{{SubscriptionID: "sub1", ResourceGroup: "rg1", ServiceName: "kv1"}}},
+		{SubscriptionID: "sub1", Subscription: true, Defender: []DefenderResult{{SubscriptionID: "sub1", Name: "KeyVaults", Tier: "Standard"}}},
+		{SubscriptionID: "sub2", ResourceGroup: "rg2", Errors: []ScanError{{SubscriptionID: "sub2", ResourceGroup: "rg2", Scanner: "st", Error: "forbidden"}}},
+	}
+	for _, e := range entries {
+		if err := checkpoint.Add(e); err != nil {
+			t.Fatalf("Checkpoint.Add() error = %v", err)
+		}
+	}
+	if err := checkpoint.Close(); err != nil {
+		t.Fatalf("Checkpoint.Close() error = %v", err)
+	}
+
+	// Simulate a crash while writing the next entry
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"subscriptionId":"sub2","resourceGroup":"rg3","res`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	checkpoint, err = LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if got := len(checkpoint.Entries()); got != 3 {
+		t.Fatalf("Checkpoint.Entries() returned %d entries, want 3", got)
+	}
+
+	tests := []struct {
+		subscriptionID string
+		resourceGroup  string
+		want           bool
+	}{
+		{"sub1", "rg1", true},
+		{"SUB1", "other", true},
+		{"sub2", "RG2", true},
+		{"sub2", "rg3", false},
+		{"sub3", "rg1", false},
+	}
+	for _, tt := range tests {
+		if got := checkpoint.HasResourceGroup(tt.subscriptionID, tt.resourceGroup); got != tt.want {
+			t.Errorf("Checkpoint.HasResourceGroup(%s, %s) = %v, want %v", tt.subscriptionID, tt.resourceGroup, got, tt.want)
+		}
+	}
+	if !checkpoint.HasSubscription("sub1") || checkpoint.HasSubscription("sub2") {
+		t.Errorf("Checkpoint.HasSubscription() returned wrong values")
+	}
+
+	if err := checkpoint.Add(CheckpointEntry{SubscriptionID: "sub2", ResourceGroup: "rg3"}); err != nil {
+		t.Fatalf("Checkpoint.Add() error = %v", err)
+	}
+	checkpoint.Close()
+
+	checkpoint, err = LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() after resume error = %v", err)
+	}
+	if !checkpoint.HasResourceGroup("sub2", "rg3") {
+		t.Errorf("Checkpoint.HasResourceGroup(sub2, rg3) = false after resume, want true")
+	}
+
+	if err := checkpoint.Remove(); err != nil {
+		t.Fatalf("Checkpoint.Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Checkpoint.Remove() did not delete %s", path)
+	}
+}
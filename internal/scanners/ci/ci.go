@@ -16,6 +16,17 @@ type ContainerInstanceScanner struct {
 	instancesClient *armcontainerinstance.ContainerGroupsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "ci",
+		Description: "Azure Container Instances",
+		ResourceTypes: []string{
+			"Microsoft.ContainerInstance/containerGroups",
+		},
+		New: func() scanners.IAzureScanner { return &ContainerInstanceScanner{} },
+	})
+}
+
 // Init - Initializes the ContainerInstanceScanner
 func (c *ContainerInstanceScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *ContainerInstanceScanner) Scan(resourceGroupName string, scanContext *s
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*instance.ID),
 			ServiceName:    *instance.Name,
+			ID:             *instance.ID,
 			Type:           *instance.Type,
 			Location:       *instance.Location,
 			Rules:          rr,
@@ -52,6 +64,23 @@ func (c *ContainerInstanceScanner) Scan(resourceGroupName string, scanContext *s
 }
 
 func (c *ContainerInstanceScanner) listInstances(resourceGroupName string) ([]*armcontainerinstance.ContainerGroup, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcontainerinstance.ContainerGroup](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.ContainerInstance/containerGroups")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.instancesClient.NewListPager(nil)
+		apps := make([]*armcontainerinstance.ContainerGroup, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			apps = append(apps, resp.Value...)
+		}
+		return apps, nil
+	}
+
 	pager := c.instancesClient.NewListByResourceGroupPager(resourceGroupName, nil)
 	apps := make([]*armcontainerinstance.ContainerGroup, 0)
 	for pager.More() {
@@ -16,6 +16,17 @@ type CognitiveScanner struct {
 	client *armcognitiveservices.AccountsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "cog",
+		Description: "Azure Cognitive Service Accounts",
+		ResourceTypes: []string{
+			"Microsoft.CognitiveServices/accounts",
+		},
+		New: func() scanners.IAzureScanner { return &CognitiveScanner{} },
+	})
+}
+
 // Init - Initializes the CognitiveScanner
 func (a *CognitiveScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,8 +52,9 @@ func (c *CognitiveScanner) Scan(resourceGroupName string, scanContext *scanners.
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*eventHub.ID),
 			ServiceName:    *eventHub.Name,
+			ID:             *eventHub.ID,
 			Type:           *eventHub.Type,
 			Location:       *eventHub.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *CognitiveScanner) Scan(resourceGroupName string, scanContext *scanners.
 }
 
 func (c *CognitiveScanner) listEventHubs(resourceGroupName string) ([]*armcognitiveservices.Account, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcognitiveservices.Account](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.CognitiveServices/accounts")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListPager(nil)
+
+		namespaces := make([]*armcognitiveservices.Account, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, resp.Value...)
+		}
+		return namespaces, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	namespaces := make([]*armcognitiveservices.Account, 0)
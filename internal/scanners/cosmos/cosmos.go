@@ -16,6 +16,17 @@ type CosmosDBScanner struct {
 	databasesClient *armcosmos.DatabaseAccountsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "cosmos",
+		Description: "Azure Cosmos DB",
+		ResourceTypes: []string{
+			"Microsoft.DocumentDB/databaseAccounts",
+		},
+		New: func() scanners.IAzureScanner { return &CosmosDBScanner{} },
+	})
+}
+
 // Init - Initializes the CosmosDBScanner
 func (a *CosmosDBScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,8 +52,9 @@ func (c *CosmosDBScanner) Scan(resourceGroupName string, scanContext *scanners.S
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*database.ID),
 			ServiceName:    *database.Name,
+			ID:             *database.ID,
 			Type:           *database.Type,
 			Location:       *database.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *CosmosDBScanner) Scan(resourceGroupName string, scanContext *scanners.S
 }
 
 func (c *CosmosDBScanner) listDatabases(resourceGroupName string) ([]*armcosmos.DatabaseAccountGetResults, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcosmos.DatabaseAccountGetResults](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DocumentDB/databaseAccounts")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.databasesClient.NewListPager(nil)
+
+		domains := make([]*armcosmos.DatabaseAccountGetResults, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			domains = append(domains, resp.Value...)
+		}
+		return domains, nil
+	}
+
 	pager := c.databasesClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	domains := make([]*armcosmos.DatabaseAccountGetResults, 0)
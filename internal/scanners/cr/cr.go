@@ -16,6 +16,17 @@ type ContainerRegistryScanner struct {
 	registriesClient *armcontainerregistry.RegistriesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "cr",
+		Description: "Azure Container Registries",
+		ResourceTypes: []string{
+			"Microsoft.ContainerRegistry/registries",
+		},
+		New: func() scanners.IAzureScanner { return &ContainerRegistryScanner{} },
+	})
+}
+
 // Init - Initializes the ContainerRegistryScanner
 func (c *ContainerRegistryScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *ContainerRegistryScanner) Scan(resourceGroupName string, scanContext *s
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*registry.ID),
 			ServiceName:    *registry.Name,
+			ID:             *registry.ID,
 			Type:           *registry.Type,
 			Location:       *registry.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *ContainerRegistryScanner) Scan(resourceGroupName string, scanContext *s
 }
 
 func (c *ContainerRegistryScanner) listRegistries(resourceGroupName string) ([]*armcontainerregistry.Registry, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcontainerregistry.Registry](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.ContainerRegistry/registries")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.registriesClient.NewListPager(nil)
+
+		registries := make([]*armcontainerregistry.Registry, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			registries = append(registries, resp.Value...)
+		}
+		return registries, nil
+	}
+
 	pager := c.registriesClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	registries := make([]*armcontainerregistry.Registry, 0)
@@ -0,0 +1,197 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// CustomRules - Declarative rules loaded from a YAML or JSON file, indexed by resource type
+	CustomRules struct {
+		rules map[string]map[string]AzureRule
+	}
+
+	customRulesFile struct {
+		Rules []CustomRuleDefinition `yaml:"rules" json:"rules"`
+	}
+
+	// CustomRuleDefinition - A declarative rule. The rule is broken when Expression
+	// does not evaluate to true for a resource of ResourceType. When set, Result is
+	// an expression whose value is reported as the rule result.
+	CustomRuleDefinition struct {
+		Id           string `yaml:"id" json:"id"`
+		ResourceType string `yaml:"resourceType" json:"resourceType"`
+		Category     string `yaml:"category" json:"category"`
+		Subcategory  string `yaml:"subcategory" json:"subcategory"`
+		Description  string `yaml:"description" json:"description"`
+		Severity     string `yaml:"severity" json:"severity"`
+		Url          string `yaml:"url" json:"url"`
+		Expression   string `yaml:"expression" json:"expression"`
+		Result       string `yaml:"result" json:"result"`
+	}
+)
+
+// LoadCustomRules - Loads the custom rules from a YAML or JSON file
+func LoadCustomRules(path string) (*CustomRules, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := customRulesFile{}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse custom rules file %s: %w", path, err)
+	}
+
+	c := &CustomRules{
+		rules: map[string]map[string]AzureRule{},
+	}
+	for _, d := range file.Rules {
+		if err := c.Add(d); err != nil {
+			return nil, fmt.Errorf("invalid custom rule %s in %s: %w", d.Id, path, err)
+		}
+	}
+
+	log.Info().Msgf("Loaded %d custom rules from %s", len(file.Rules), path)
+	return c, nil
+}
+
+// Add - Compiles a rule definition and adds it to the rules of its resource type
+func (c *CustomRules) Add(d CustomRuleDefinition) error {
+	if d.Id == "" || d.ResourceType == "" || d.Description == "" || d.Category == "" || d.Expression == "" {
+		return errors.New("id, resourceType, category, description and expression are required")
+	}
+
+	severity, err := ParseSeverity(d.Severity)
+	if err != nil {
+		return err
+	}
+
+	for _, rules := range c.rules {
+		if _, exists := rules[d.Id]; exists {
+			return fmt.Errorf("duplicate rule id %s", d.Id)
+		}
+	}
+
+	expression, err := CompileExpression(d.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+
+	var result *Expression
+	if d.Result != "" {
+		result, err = CompileExpression(d.Result)
+		if err != nil {
+			return fmt.Errorf("invalid result: %w", err)
+		}
+	}
+
+	resourceType := strings.ToLower(d.ResourceType)
+	if c.rules[resourceType] == nil {
+		c.rules[resourceType] = map[string]AzureRule{}
+	}
+	c.rules[resourceType][d.Id] = AzureRule{
+		Id:          d.Id,
+		Category:    d.Category,
+		Subcategory: d.Subcategory,
+		Description: d.Description,
+		Severity:    severity,
+		Url:         d.Url,
+		Eval: func(target interface{}, scanContext *ScanContext) (bool, string) {
+			doc, ok := target.(map[string]interface{})
+			if !ok {
+				doc, ok = toDocument(target)
+			}
+			if !ok {
+				return true, "resource could not be evaluated"
+			}
+
+			broken := !expression.EvalBool(doc)
+			if result == nil {
+				return broken, ""
+			}
+			return broken, formatExpressionValue(result.Eval(doc))
+		},
+	}
+	return nil
+}
+
+// GetRules - Returns all custom rules keyed by Id
+func (c *CustomRules) GetRules() map[string]AzureRule {
+	all := map[string]AzureRule{}
+	if c == nil {
+		return all
+	}
+	for _, rules := range c.rules {
+		for k, r := range rules {
+			all[k] = r
+		}
+	}
+	return all
+}
+
+// ForType - Returns the custom rules targeting a resource type
+func (c *CustomRules) ForType(resourceType string) map[string]AzureRule {
+	if c == nil {
+		return nil
+	}
+	return c.rules[strings.ToLower(resourceType)]
+}
+
+// evaluate - Evaluates the custom rules matching the type of an ARM model
+func (c *CustomRules) evaluate(e *RuleEngine, target interface{}, scanContext *ScanContext, results map[string]AzureRuleResult) {
+	if c == nil || len(c.rules) == 0 {
+		return
+	}
+
+	doc, ok := toDocument(target)
+	if !ok {
+		return
+	}
+	resourceType, _ := doc["type"].(string)
+	for k, rule := range c.ForType(resourceType) {
+		if _, exists := results[k]; exists || !scanContext.Filter.Matches(rule) {
+			continue
+		}
+		results[k] = e.EvaluateRule(rule, doc, scanContext)
+	}
+}
+
+// toDocument - Converts an ARM model to its JSON representation
+func toDocument(target interface{}) (map[string]interface{}, bool) {
+	content, err := json.Marshal(target)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to marshal resource for custom rules")
+		return nil, false
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+func formatExpressionValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64, bool:
+		return fmt.Sprint(t)
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(content)
+}
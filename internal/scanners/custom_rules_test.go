@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+)
+
+func TestCompileExpression(t *testing.T) {
+	doc := map[string]interface{}{
+		"location": "westeurope",
+		"tags":     map[string]interface{}{"cost-center": "1234", "env": "prod"},
+		"sku":      map[string]interface{}{"name": "Standard", "capacity": float64(2)},
+		"properties": map[string]interface{}{
+			"subnets": []interface{}{
+				map[string]interface{}{"name": "snet-a"},
+				map[string]interface{}{"name": "snet-b"},
+			},
+		},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"$.location == 'westeurope'", true},
+		{"$.location in ['northeurope', 'westeurope']", true},
+		{"$.location in ['eastus']", false},
+		{"$.tags['cost-center'] != null", true},
+		{"exists($.tags.owner)", false},
+		{"$.tags contains 'env' && $.tags.env == 'prod'", true},
+		{"lower($.sku.name) == 'standard'", true},
+		{"$.sku.capacity >= 2 && $.sku.capacity < 3", true},
+		{"!($.sku.capacity > 2)", true},
+		{"$.properties.subnets[*].name contains 'snet-b'", true},
+		{"$.properties.subnets[*].name in ['snet-a', 'snet-b']", true},
+		{"size($.properties.subnets) == 2", true},
+		{"$.properties.subnets[0].name matches '^snet-[a-z]$'", true},
+		{"$.location startsWith 'west' || $.missing.value == 1", true},
+		{"$.missing.value == 1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.EvalBool(doc); got != tt.want {
+				t.Errorf("Expression.EvalBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"$.location ==", "$.location == 'west", "exists('x')", "$.a matches '['", "($.a == 1"} {
+		if _, err := CompileExpression(invalid); err == nil {
+			t.Errorf("CompileExpression(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	content := `rules:
+  - id: org-001
+    resourceType: Microsoft.KeyVault/vaults
+    category: Operational Excellence
+    subcategory: Tags
+    description: Key Vault should have a cost-center tag
+    severity: medium
+    expression: $.tags['cost-center'] != null
+    result: $.tags['cost-center']
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	customRules, err := LoadCustomRules(path)
+	if err != nil {
+		t.Fatalf("LoadCustomRules() error = %v", err)
+	}
+
+	scanContext := &ScanContext{CustomRules: customRules}
+	engine := RuleEngine{}
+
+	vault := &armkeyvault.Vault{
+		ID:   ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv"),
+		Type: ref.Of("Microsoft.KeyVault/vaults"),
+		Tags: map[string]*string{"cost-center": ref.Of("1234")},
+	}
+	results := engine.EvaluateRules(map[string]AzureRule{}, vault, scanContext)
+	if r, ok := results["org-001"]; !ok || r.IsBroken || r.Result != "1234" || r.Severity != SeverityMedium {
+		t.Errorf("EvaluateRules() = %v, want org-001 not broken with result 1234", results)
+	}
+
+	vault.Tags = nil
+	results = engine.EvaluateRules(map[string]AzureRule{}, vault, scanContext)
+	if r := results["org-001"]; !r.IsBroken {
+		t.Errorf("EvaluateRules() = %v, want org-001 broken", results)
+	}
+
+	vault.Type = ref.Of("Microsoft.Storage/storageAccounts")
+	results = engine.EvaluateRules(map[string]AzureRule{}, vault, scanContext)
+	if _, ok := results["org-001"]; ok {
+		t.Errorf("EvaluateRules() = %v, want no custom rule for another resource type", results)
+	}
+
+	vault.Type = ref.Of("Microsoft.KeyVault/vaults")
+	scanContext.Recording = true
+	rules := map[string]AzureRule{
+		"kv-001": {
+			Id: "kv-001",
+			Eval: func(target interface{}, scanContext *ScanContext) (bool, string) {
+				t.Errorf("kv-001 evaluated while recording a snapshot")
+				return false, ""
+			},
+		},
+	}
+	results = engine.EvaluateRules(rules, vault, scanContext)
+	if len(results) != 0 {
+		t.Errorf("EvaluateRules() = %v, want no result while recording a snapshot", results)
+	}
+}
@@ -16,6 +16,17 @@ type DatabricksScanner struct {
 	client *armdatabricks.WorkspacesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "dbw",
+		Description: "Azure Databricks",
+		ResourceTypes: []string{
+			"Microsoft.Databricks/workspaces",
+		},
+		New: func() scanners.IAzureScanner { return &DatabricksScanner{} },
+	})
+}
+
 // Init - Initializes the DatabricksScanner
 func (c *DatabricksScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *DatabricksScanner) Scan(resourceGroupName string, scanContext *scanners
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*ws.ID),
 			ServiceName:    *ws.Name,
+			ID:             *ws.ID,
 			Type:           *ws.Type,
 			Location:       *ws.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *DatabricksScanner) Scan(resourceGroupName string, scanContext *scanners
 }
 
 func (c *DatabricksScanner) listWorkspaces(resourceGroupName string) ([]*armdatabricks.Workspace, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armdatabricks.Workspace](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Databricks/workspaces")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListBySubscriptionPager(nil)
+
+		registries := make([]*armdatabricks.Workspace, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			registries = append(registries, resp.Value...)
+		}
+		return registries, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	registries := make([]*armdatabricks.Workspace, 0)
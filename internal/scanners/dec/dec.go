@@ -15,6 +15,17 @@ type DataExplorerScanner struct {
 	client *armkusto.ClustersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "dec",
+		Description: "Azure Data Explorer",
+		ResourceTypes: []string{
+			"Microsoft.Kusto/clusters",
+		},
+		New: func() scanners.IAzureScanner { return &DataExplorerScanner{} },
+	})
+}
+
 // Init - Initializes the FrontDoor Scanner
 func (a *DataExplorerScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -40,10 +51,11 @@ func (a *DataExplorerScanner) Scan(resourceGroupName string, scanContext *scanne
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
 			Location:       *g.Location,
 			Type:           *g.Type,
 			ServiceName:    *g.Name,
+			ID:             *g.ID,
 			Rules:          rr,
 		})
 	}
@@ -51,6 +63,24 @@ func (a *DataExplorerScanner) Scan(resourceGroupName string, scanContext *scanne
 }
 
 func (a *DataExplorerScanner) listClusters(resourceGroupName string) ([]*armkusto.Cluster, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armkusto.Cluster](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Kusto/clusters")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.client.NewListPager(nil)
+
+		kustoclusters := make([]*armkusto.Cluster, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			kustoclusters = append(kustoclusters, resp.Value...)
+		}
+		return kustoclusters, nil
+	}
+
 	pager := a.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	kustoclusters := make([]*armkusto.Cluster, 0)
@@ -5,7 +5,6 @@ package scanners
 
 import (
 	"context"
-	"math"
 	"net/http"
 	"strings"
 	"sync"
@@ -13,6 +12,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/applicationinsights/armapplicationinsights"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/rs/zerolog/log"
 )
@@ -35,35 +35,60 @@ func (s *DiagnosticSettingsScanner) Init(config *ScannerConfig) error {
 	return nil
 }
 
-// ListResourcesWithDiagnosticSettings - Lists all resources with diagnostic settings
-func (s *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[string]bool, error) {
+// ListResourcesWithDiagnosticSettings - Lists all resources with diagnostic settings, and the number of
+// resources sending their data to each Log Analytics workspace, by lowercase workspace ID: the resources
+// with a diagnostic setting for the workspace and the workspace-based Application Insights components
+func (s *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[string]bool, map[string]int, error) {
 	resources := []string{}
 	res := map[string]bool{}
+	workspaces := workspaceResources{}
+
+	if s.config.Snapshot != nil {
+		log.Info().Msg("Preflight: Loading Diagnostic Settings from snapshot")
+		settings, err := ListFromSnapshot[armmonitor.DiagnosticSettingsResource](s.config.Snapshot, s.config.SubscriptionID, "", "Microsoft.Insights/diagnosticSettings")
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, d := range settings {
+			res[parseResourceId(d.ID)] = true
+			workspaces.addDiagnosticSetting(d)
+		}
+		components, err := ListFromSnapshot[armapplicationinsights.Component](s.config.Snapshot, s.config.SubscriptionID, "", "Microsoft.Insights/components")
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range components {
+			if c.ID != nil && c.Properties != nil && c.Properties.WorkspaceResourceID != nil {
+				workspaces.add(*c.Properties.WorkspaceResourceID, *c.ID)
+			}
+		}
+		return res, workspaces.count(), nil
+	}
 
 	log.Info().Msg("Preflight: Scanning Resource Ids")
-	graphQuery := GraphQuery{}
-	result := graphQuery.Query(s.config.Ctx, s.config.Cred, "resources | project id", []*string{&s.config.SubscriptionID})
+	graphQuery := GraphQuery{ClientOptions: s.config.ClientOptions}
+	result, err := graphQuery.Query(s.config.Ctx, s.config.Cred, "resources | project id, workspace = tostring(properties.WorkspaceResourceId)", []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if result == nil || result.Data == nil {
+	if len(result.Data) == 0 {
 		log.Info().Msg("Preflight: No resources found")
-		return res, nil
+		return res, workspaces.count(), nil
 	}
 
 	for _, row := range result.Data {
 		m := row.(map[string]interface{})
 		resources = append(resources, strings.ToLower(m["id"].(string)))
+		// Workspace-based Application Insights components
+		if workspace, ok := m["workspace"].(string); ok {
+			workspaces.add(workspace, m["id"].(string))
+		}
 	}
 
-	batches := int(math.Ceil(float64(len(resources)) / 20))
-
-	var wg sync.WaitGroup
-	ch := make(chan map[string]bool, 5)
-	wg.Add(batches)
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
+	var mutex sync.Mutex
+	var batchErr error
+	tasks := []func(){}
 
 	log.Info().Msg("Preflight: Scanning Diagnostic Settings")
 	// Split resources into batches of 20 items.
@@ -73,30 +98,63 @@ func (s *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[s
 		if j > len(resources) {
 			j = len(resources)
 		}
-		go func(r []string) {
-			defer wg.Done()
+		r := resources[i:j]
+		tasks = append(tasks, func() {
 			resp, err := s.restCall(s.config.Ctx, r, s.config.Cred, s.config.ClientOptions)
+
+			mutex.Lock()
+			defer mutex.Unlock()
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to get diagnostic settings")
+				batchErr = err
+				return
 			}
-			asyncRes := map[string]bool{}
 			for _, response := range resp.Responses {
 				for _, diagnosticSetting := range response.Content.Value {
-					id := parseResourceId(diagnosticSetting.ID)
-					asyncRes[id] = true
+					res[parseResourceId(diagnosticSetting.ID)] = true
+					workspaces.addDiagnosticSetting(diagnosticSetting)
 				}
 			}
-			ch <- asyncRes
-		}(resources[i:j])
+		})
 	}
 
-	for i := 0; i < batches; i++ {
-		for k, v := range <-ch {
-			res[k] = v
-		}
+	// The batches share the worker pool of the scan, so a subscription
+	// with thousands of resources does not flood ARM with batch requests.
+	s.config.Pool.Run(tasks...)
+
+	if batchErr != nil {
+		return nil, nil, batchErr
 	}
+	return res, workspaces.count(), nil
+}
 
-	return res, nil
+// workspaceResources - Resources sending their data to each Log Analytics workspace, by lowercase IDs
+type workspaceResources map[string]map[string]bool
+
+func (w workspaceResources) add(workspaceID, resourceID string) {
+	if workspaceID == "" {
+		return
+	}
+	workspaceID = strings.ToLower(workspaceID)
+	if w[workspaceID] == nil {
+		w[workspaceID] = map[string]bool{}
+	}
+	w[workspaceID][strings.ToLower(resourceID)] = true
+}
+
+func (w workspaceResources) addDiagnosticSetting(d *armmonitor.DiagnosticSettingsResource) {
+	if d.ID != nil && d.Properties != nil && d.Properties.WorkspaceID != nil {
+		w.add(*d.Properties.WorkspaceID, parseResourceId(d.ID))
+	}
+}
+
+// count - Returns the number of resources of each workspace. Several diagnostic settings
+// of a resource for the same workspace count once.
+func (w workspaceResources) count() map[string]int {
+	res := map[string]int{}
+	for workspaceID, resources := range w {
+		res[workspaceID] = len(resources)
+	}
+	return res
 }
 
 const (
@@ -149,7 +207,7 @@ func (s *DiagnosticSettingsScanner) restCall(ctx context.Context, resourceIds []
 
 func parseResourceId(diagnosticSettingID *string) string {
 	id := *diagnosticSettingID
-	i := strings.Index(id, "/providers/microsoft.insights/diagnosticSettings/")
+	i := strings.Index(strings.ToLower(id), "/providers/microsoft.insights/diagnosticsettings/")
 	return strings.ToLower(id[:i])
 }
 
@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	snapshotWorkspace1         = "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/log-1"
+	snapshotWorkspace2         = "/subscriptions/sub2/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/log-2"
+	snapshotDiagnosticSettings = `{"value": [
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-1/providers/microsoft.insights/diagnosticSettings/ds-1", "name": "ds-1", "type": "Microsoft.Insights/diagnosticSettings", "properties": {"workspaceId": "` + snapshotWorkspace1 + `"}},
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-1/providers/microsoft.insights/diagnosticSettings/ds-2", "name": "ds-2", "type": "Microsoft.Insights/diagnosticSettings", "properties": {"workspaceId": "` + snapshotWorkspace1 + `"}},
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/kv-2/providers/microsoft.insights/diagnosticSettings/ds-1", "name": "ds-1", "type": "Microsoft.Insights/diagnosticSettings", "properties": {"workspaceId": "` + snapshotWorkspace2 + `"}},
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/st1/providers/microsoft.insights/diagnosticSettings/ds-1", "name": "ds-1", "type": "Microsoft.Insights/diagnosticSettings", "properties": {"storageAccountId": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/stlogs"}}
+	]}`
+	snapshotComponents = `{"value": [
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Insights/components/appi-1", "name": "appi-1", "type": "Microsoft.Insights/components", "kind": "web", "properties": {"WorkspaceResourceId": "` + snapshotWorkspace1 + `"}},
+		{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Insights/components/appi-2", "name": "appi-2", "type": "Microsoft.Insights/components", "kind": "web", "properties": {}}
+	]}`
+)
+
+func TestDiagnosticSettingsScanner_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"diagnostics.json": snapshotDiagnosticSettings,
+		"components.json":  snapshotComponents,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snapshot, err := LoadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	s := DiagnosticSettingsScanner{}
+	if err := s.Init(&ScannerConfig{SubscriptionID: "sub1", Snapshot: snapshot}); err != nil {
+		t.Fatalf("DiagnosticSettingsScanner.Init() error = %v", err)
+	}
+	resources, workspaces, err := s.ListResourcesWithDiagnosticSettings()
+	if err != nil {
+		t.Fatalf("ListResourcesWithDiagnosticSettings() error = %v", err)
+	}
+
+	wantResources := map[string]bool{
+		"/subscriptions/sub1/resourcegroups/rg1/providers/microsoft.keyvault/vaults/kv-1":        true,
+		"/subscriptions/sub1/resourcegroups/rg1/providers/microsoft.keyvault/vaults/kv-2":        true,
+		"/subscriptions/sub1/resourcegroups/rg1/providers/microsoft.storage/storageaccounts/st1": true,
+	}
+	if !reflect.DeepEqual(resources, wantResources) {
+		t.Errorf("ListResourcesWithDiagnosticSettings() resources = %v, want %v", resources, wantResources)
+	}
+
+	// kv-1 has two settings for log-1 and counts once, with the workspace-based appi-1
+	wantWorkspaces := map[string]int{
+		"/subscriptions/sub1/resourcegroups/rg1/providers/microsoft.operationalinsights/workspaces/log-1": 2,
+		"/subscriptions/sub2/resourcegroups/rg1/providers/microsoft.operationalinsights/workspaces/log-2": 1,
+	}
+	if !reflect.DeepEqual(workspaces, wantWorkspaces) {
+		t.Errorf("ListResourcesWithDiagnosticSettings() workspaces = %v, want %v", workspaces, wantWorkspaces)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package disk
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+// DiskScanner - Scanner for Managed Disks and Snapshots
+type DiskScanner struct {
+	config         *scanners.ScannerConfig
+	diskClient     *armcompute.DisksClient
+	snapshotClient *armcompute.SnapshotsClient
+	vmClient       *armcompute.VirtualMachinesClient
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "disk",
+		Description: "Azure Managed Disks and Snapshots",
+		ResourceTypes: []string{
+			"Microsoft.Compute/disks",
+			"Microsoft.Compute/snapshots",
+		},
+		New: func() scanners.IAzureScanner { return &DiskScanner{} },
+	})
+}
+
+// Init - Initializes the DiskScanner
+func (c *DiskScanner) Init(config *scanners.ScannerConfig) error {
+	c.config = config
+	var err error
+	c.diskClient, err = armcompute.NewDisksClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.snapshotClient, err = armcompute.NewSnapshotsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.vmClient, err = armcompute.NewVirtualMachinesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Managed Disks and Snapshots in a Resource Group
+func (c *DiskScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Managed Disks and Snapshots in Resource Group %s", resourceGroupName)
+
+	disks, err := c.listDisks(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	encryptionAtHost, err := c.listEncryptionAtHost(disks)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := c.listSnapshots(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := scanners.RuleEngine{}
+	diskRules := c.getDiskRules(encryptionAtHost)
+	snapshotRules := c.getSnapshotRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, d := range disks {
+		rr := engine.EvaluateRules(diskRules, d, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*d.ID),
+			ServiceName:    *d.Name,
+			ID:             *d.ID,
+			Type:           *d.Type,
+			Location:       *d.Location,
+			Rules:          rr,
+		})
+	}
+
+	for _, s := range snapshots {
+		rr := engine.EvaluateRules(snapshotRules, s, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*s.ID),
+			ServiceName:    *s.Name,
+			ID:             *s.ID,
+			Type:           *s.Type,
+			Location:       *s.Location,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (c *DiskScanner) listDisks(resourceGroupName string) ([]*armcompute.Disk, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcompute.Disk](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Compute/disks")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.diskClient.NewListPager(nil)
+
+		disks := make([]*armcompute.Disk, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			disks = append(disks, resp.Value...)
+		}
+		return disks, nil
+	}
+
+	pager := c.diskClient.NewListByResourceGroupPager(resourceGroupName, nil)
+
+	disks := make([]*armcompute.Disk, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, resp.Value...)
+	}
+	return disks, nil
+}
+
+func (c *DiskScanner) listSnapshots(resourceGroupName string) ([]*armcompute.Snapshot, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcompute.Snapshot](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Compute/snapshots")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.snapshotClient.NewListPager(nil)
+
+		snapshots := make([]*armcompute.Snapshot, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, resp.Value...)
+		}
+		return snapshots, nil
+	}
+
+	pager := c.snapshotClient.NewListByResourceGroupPager(resourceGroupName, nil)
+
+	snapshots := make([]*armcompute.Snapshot, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, resp.Value...)
+	}
+	return snapshots, nil
+}
+
+// listEncryptionAtHost - Returns whether encryption at host is enabled for the Virtual Machines
+// attached to disks that are not encrypted with a customer-managed key, by lowercase VM ID
+func (c *DiskScanner) listEncryptionAtHost(disks []*armcompute.Disk) (map[string]bool, error) {
+	res := map[string]bool{}
+
+	resourceGroups := map[string]bool{}
+	for _, d := range disks {
+		if d.ManagedBy == nil || (d.Properties != nil && hasCustomerManagedKey(d.Properties.Encryption)) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(*d.ManagedBy), "/providers/microsoft.compute/virtualmachines/") {
+			continue
+		}
+		resourceGroups[scanners.GetResourceGroupFromResourceID(*d.ManagedBy)] = true
+	}
+
+	for resourceGroupName := range resourceGroups {
+		vms, err := c.listVirtualMachines(resourceGroupName)
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range vms {
+			res[strings.ToLower(*vm.ID)] = vm.Properties != nil &&
+				vm.Properties.SecurityProfile != nil &&
+				vm.Properties.SecurityProfile.EncryptionAtHost != nil &&
+				*vm.Properties.SecurityProfile.EncryptionAtHost
+		}
+	}
+	return res, nil
+}
+
+func (c *DiskScanner) listVirtualMachines(resourceGroupName string) ([]*armcompute.VirtualMachine, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcompute.VirtualMachine](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Compute/virtualMachines")
+	}
+
+	pager := c.vmClient.NewListPager(resourceGroupName, nil)
+
+	vms := make([]*armcompute.VirtualMachine, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		vms = append(vms, resp.Value...)
+	}
+	return vms, nil
+}
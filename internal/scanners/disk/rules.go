@@ -0,0 +1,209 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package disk
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+// GetRules - Returns the rules for the DiskScanner
+func (a *DiskScanner) GetRules() map[string]scanners.AzureRule {
+	rules := a.getDiskRules(map[string]bool{})
+	for k, v := range a.getSnapshotRules() {
+		rules[k] = v
+	}
+	return rules
+}
+
+// getDiskRules - Returns the rules for Managed Disks. encryptionAtHost tells, by lowercase
+// VM ID, whether the Virtual Machine of an attached disk has encryption at host enabled.
+func (a *DiskScanner) getDiskRules(encryptionAtHost map[string]bool) map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"disk-001": {
+			Id:          "disk-001",
+			Category:    scanners.RulesCategoryCostOptimization,
+			Subcategory: scanners.RulesSubcategoryCostOptimizationUnusedResources,
+			Description: "Managed Disk should be attached to a Virtual Machine",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				unattached := d.Properties != nil && d.Properties.DiskState != nil && *d.Properties.DiskState == armcompute.DiskStateUnattached
+				return unattached, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-find-unattached-portal",
+		},
+		"disk-002": {
+			Id:          "disk-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityAvailabilityZones,
+			Description: "Managed Disk attached to a Virtual Machine should use zone-redundant storage (ZRS)",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				sku := diskSKU(d)
+				// Zonal or not, an LRS disk is lost with its zone. Only Premium SSD and Standard SSD
+				// have a ZRS option: Ultra Disks, Premium SSD v2 and Standard HDD are LRS only.
+				lrs := sku == armcompute.DiskStorageAccountTypesPremiumLRS || sku == armcompute.DiskStorageAccountTypesStandardSSDLRS
+				return d.ManagedBy != nil && lrs, string(sku)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-redundancy#zone-redundant-storage-for-managed-disks",
+		},
+		"disk-003": {
+			Id:          "disk-003",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityEncryption,
+			Description: "Managed Disk should be encrypted with a customer-managed key or attached to a Virtual Machine with encryption at host",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				if d.Properties != nil && hasCustomerManagedKey(d.Properties.Encryption) {
+					return false, ""
+				}
+				if d.ManagedBy != nil && encryptionAtHost[strings.ToLower(*d.ManagedBy)] {
+					return false, ""
+				}
+				return true, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disk-encryption-overview",
+		},
+		"disk-004": {
+			Id:          "disk-004",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworking,
+			Description: "Managed Disk should disable public network access",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				if d.Properties == nil {
+					return true, ""
+				}
+				return isPublicNetworkAccessEnabled(d.Properties.PublicNetworkAccess, d.Properties.NetworkAccessPolicy), ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-enable-private-links-for-import-export-portal",
+		},
+		"disk-005": {
+			Id:          "disk-005",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySKU,
+			Description: "Managed Disk of a production workload should not use Standard HDD",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				sku := diskSKU(d)
+				return isProduction(d.Tags) && sku == armcompute.DiskStorageAccountTypesStandardLRS, string(sku)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-types#standard-hdds",
+		},
+		"disk-006": {
+			Id:          "disk-006",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Managed Disk should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				d := target.(*armcompute.Disk)
+				return len(d.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
+
+func (a *DiskScanner) getSnapshotRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"snap-001": {
+			Id:          "snap-001",
+			Category:    scanners.RulesCategoryCostOptimization,
+			Subcategory: scanners.RulesSubcategoryCostOptimizationStorage,
+			Description: "Snapshot should be incremental",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				s := target.(*armcompute.Snapshot)
+				incremental := s.Properties != nil && s.Properties.Incremental != nil && *s.Properties.Incremental
+				return !incremental, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-incremental-snapshots",
+		},
+		"snap-002": {
+			Id:          "snap-002",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityEncryption,
+			Description: "Snapshot should be encrypted with a customer-managed key",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				s := target.(*armcompute.Snapshot)
+				cmk := s.Properties != nil && hasCustomerManagedKey(s.Properties.Encryption)
+				return !cmk, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disk-encryption#customer-managed-keys",
+		},
+		"snap-003": {
+			Id:          "snap-003",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworking,
+			Description: "Snapshot should disable public network access",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				s := target.(*armcompute.Snapshot)
+				if s.Properties == nil {
+					return true, ""
+				}
+				return isPublicNetworkAccessEnabled(s.Properties.PublicNetworkAccess, s.Properties.NetworkAccessPolicy), ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/disks-enable-private-links-for-import-export-portal",
+		},
+		"snap-004": {
+			Id:          "snap-004",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Snapshot should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				s := target.(*armcompute.Snapshot)
+				return len(s.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
+
+func diskSKU(d *armcompute.Disk) armcompute.DiskStorageAccountTypes {
+	if d.SKU == nil || d.SKU.Name == nil {
+		return ""
+	}
+	return *d.SKU.Name
+}
+
+// hasCustomerManagedKey - Returns true if the encryption uses a customer-managed key
+func hasCustomerManagedKey(e *armcompute.Encryption) bool {
+	return e != nil && e.Type != nil && *e.Type != armcompute.EncryptionTypeEncryptionAtRestWithPlatformKey
+}
+
+// isPublicNetworkAccessEnabled - Returns true if the disk or snapshot can be imported or exported from any network
+func isPublicNetworkAccessEnabled(publicNetworkAccess *armcompute.PublicNetworkAccess, networkAccessPolicy *armcompute.NetworkAccessPolicy) bool {
+	if publicNetworkAccess != nil && *publicNetworkAccess == armcompute.PublicNetworkAccessDisabled {
+		return false
+	}
+	// AllowAll is the default policy
+	return networkAccessPolicy == nil || *networkAccessPolicy == armcompute.NetworkAccessPolicyAllowAll
+}
+
+// isProduction - Returns true if an env or environment tag marks the resource as production
+func isProduction(tags map[string]*string) bool {
+	for k, v := range tags {
+		if v == nil {
+			continue
+		}
+		switch strings.ToLower(k) {
+		case "env", "environment":
+			if strings.HasPrefix(strings.ToLower(*v), "prod") {
+				return true
+			}
+		}
+	}
+	return false
+}
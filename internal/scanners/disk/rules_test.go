@@ -0,0 +1,361 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package disk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+func TestDiskScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "DiskScanner unattached disk",
+			fields: fields{
+				rule: "disk-001",
+				target: &armcompute.Disk{
+					Properties: &armcompute.DiskProperties{
+						DiskState: ref.Of(armcompute.DiskStateUnattached),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner attached disk",
+			fields: fields{
+				rule: "disk-001",
+				target: &armcompute.Disk{
+					Properties: &armcompute.DiskProperties{
+						DiskState: ref.Of(armcompute.DiskStateAttached),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner zonal LRS disk",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					ManagedBy: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm1"),
+					Zones:     []*string{ref.Of("1")},
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesPremiumLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Premium_LRS",
+			},
+		},
+		{
+			name: "DiskScanner regional LRS disk",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					ManagedBy: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm1"),
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesStandardSSDLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "StandardSSD_LRS",
+			},
+		},
+		{
+			name: "DiskScanner unattached LRS disk",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesPremiumLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Premium_LRS",
+			},
+		},
+		{
+			name: "DiskScanner Standard HDD disk",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					ManagedBy: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm1"),
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesStandardLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Standard_LRS",
+			},
+		},
+		{
+			name: "DiskScanner zonal Ultra Disk",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					Zones: []*string{ref.Of("1")},
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesUltraSSDLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "UltraSSD_LRS",
+			},
+		},
+		{
+			name: "DiskScanner ZRS disk without zones",
+			fields: fields{
+				rule: "disk-002",
+				target: &armcompute.Disk{
+					ManagedBy: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm1"),
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesPremiumZRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Premium_ZRS",
+			},
+		},
+		{
+			name: "DiskScanner platform-managed key",
+			fields: fields{
+				rule: "disk-003",
+				target: &armcompute.Disk{
+					ManagedBy: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm1"),
+					Properties: &armcompute.DiskProperties{
+						Encryption: &armcompute.Encryption{
+							Type: ref.Of(armcompute.EncryptionTypeEncryptionAtRestWithPlatformKey),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner customer-managed key",
+			fields: fields{
+				rule: "disk-003",
+				target: &armcompute.Disk{
+					Properties: &armcompute.DiskProperties{
+						Encryption: &armcompute.Encryption{
+							Type: ref.Of(armcompute.EncryptionTypeEncryptionAtRestWithCustomerKey),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner public network access",
+			fields: fields{
+				rule: "disk-004",
+				target: &armcompute.Disk{
+					Properties: &armcompute.DiskProperties{
+						NetworkAccessPolicy: ref.Of(armcompute.NetworkAccessPolicyAllowAll),
+						PublicNetworkAccess: ref.Of(armcompute.PublicNetworkAccessEnabled),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner private network access",
+			fields: fields{
+				rule: "disk-004",
+				target: &armcompute.Disk{
+					Properties: &armcompute.DiskProperties{
+						NetworkAccessPolicy: ref.Of(armcompute.NetworkAccessPolicyAllowPrivate),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner Standard HDD in production",
+			fields: fields{
+				rule: "disk-005",
+				target: &armcompute.Disk{
+					Tags: map[string]*string{
+						"Environment": ref.Of("Production"),
+					},
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesStandardLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Standard_LRS",
+			},
+		},
+		{
+			name: "DiskScanner Standard HDD in development",
+			fields: fields{
+				rule: "disk-005",
+				target: &armcompute.Disk{
+					Tags: map[string]*string{
+						"env": ref.Of("dev"),
+					},
+					SKU: &armcompute.DiskSKU{
+						Name: ref.Of(armcompute.DiskStorageAccountTypesStandardLRS),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Standard_LRS",
+			},
+		},
+		{
+			name: "DiskScanner without tags",
+			fields: fields{
+				rule:        "disk-006",
+				target:      &armcompute.Disk{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner full snapshot",
+			fields: fields{
+				rule: "snap-001",
+				target: &armcompute.Snapshot{
+					Properties: &armcompute.SnapshotProperties{
+						Incremental: ref.Of(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner snapshot with platform-managed key",
+			fields: fields{
+				rule: "snap-002",
+				target: &armcompute.Snapshot{
+					Properties: &armcompute.SnapshotProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner snapshot with public network access disabled",
+			fields: fields{
+				rule: "snap-003",
+				target: &armcompute.Snapshot{
+					Properties: &armcompute.SnapshotProperties{
+						PublicNetworkAccess: ref.Of(armcompute.PublicNetworkAccessDisabled),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DiskScanner snapshot with tags",
+			fields: fields{
+				rule: "snap-004",
+				target: &armcompute.Snapshot{
+					Tags: map[string]*string{
+						"env": ref.Of("prod"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &DiskScanner{}
+			rules := s.GetRules()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiskScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
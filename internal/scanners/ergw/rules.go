@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package ergw
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// GetRules - Returns the rules for the ExpressRouteGatewayScanner
+func (a *ExpressRouteGatewayScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"ergw-001": {
+			Id:          "ergw-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "ExpressRoute Gateway should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*armnetwork.VirtualNetworkGateway)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/expressroute/monitor-expressroute",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"ergw-002": {
+			Id:          "ergw-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityAvailabilityZones,
+			Description: "ExpressRoute Gateway should use a zone-redundant SKU",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return !scanners.IsZoneRedundantGateway(g), ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/expressroute/expressroute-about-virtual-network-gateways#zrgw",
+			Field: scanners.OverviewFieldAZ,
+		},
+		"ergw-003": {
+			Id:          "ergw-003",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+			Description: "ExpressRoute Gateway SLA",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				sla := "99.95%"
+				if scanners.IsZoneRedundantGateway(g) {
+					sla = "99.99%"
+				}
+				return false, sla
+			},
+			Url:   "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
+			Field: scanners.OverviewFieldSLA,
+		},
+		"ergw-004": {
+			Id:          "ergw-004",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySKU,
+			Description: "ExpressRoute Gateway SKU",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return false, string(scanners.GetGatewaySKU(g))
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/expressroute/expressroute-about-virtual-network-gateways#gwsku",
+			Field: scanners.OverviewFieldSKU,
+		},
+		"ergw-005": {
+			Id:          "ergw-005",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryReliability,
+			Description: "ExpressRoute Gateway should be configured in active-active mode",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				active := g.Properties != nil && g.Properties.Active != nil && *g.Properties.Active
+				return !active, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/expressroute/designing-for-high-availability-with-expressroute",
+		},
+		"ergw-006": {
+			Id:          "ergw-006",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "ExpressRoute Gateway Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				caf := strings.HasPrefix(*g.Name, "ergw") || strings.HasPrefix(*g.Name, "vgw")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"ergw-007": {
+			Id:          "ergw-007",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "ExpressRoute Gateway should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return len(g.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package ergw
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+func TestExpressRouteGatewayScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "ExpressRouteGatewayScanner DiagnosticSettings",
+			fields: fields{
+				rule: "ergw-001",
+				target: &armnetwork.VirtualNetworkGateway{
+					ID: ref.Of("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner AvailabilityZones",
+			fields: fields{
+				rule: "ergw-002",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU(armnetwork.VirtualNetworkGatewaySKUNameErGw1AZ),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner AvailabilityZones ErGwScale",
+			fields: fields{
+				rule: "ergw-002",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU("ErGwScale"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner AvailabilityZones not zone-redundant",
+			fields: fields{
+				rule: "ergw-002",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU(armnetwork.VirtualNetworkGatewaySKUNameStandard),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner SLA 99.99%",
+			fields: fields{
+				rule: "ergw-003",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU(armnetwork.VirtualNetworkGatewaySKUNameErGw1AZ),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.99%",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner SLA 99.95%",
+			fields: fields{
+				rule: "ergw-003",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU(armnetwork.VirtualNetworkGatewaySKUNameHighPerformance),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.95%",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner SKU",
+			fields: fields{
+				rule: "ergw-004",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						SKU: &armnetwork.VirtualNetworkGatewaySKU{
+							Name: getSKU(armnetwork.VirtualNetworkGatewaySKUNameUltraPerformance),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "UltraPerformance",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner active-active",
+			fields: fields{
+				rule: "ergw-005",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						Active: ref.Of(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner active-standby",
+			fields: fields{
+				rule: "ergw-005",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						Active: ref.Of(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner CAF",
+			fields: fields{
+				rule: "ergw-006",
+				target: &armnetwork.VirtualNetworkGateway{
+					Name: ref.Of("ergw-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteGatewayScanner Tags",
+			fields: fields{
+				rule:        "ergw-007",
+				target:      &armnetwork.VirtualNetworkGateway{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ExpressRouteGatewayScanner{}
+			rules := s.GetRules()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpressRouteGatewayScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func getSKU(s armnetwork.VirtualNetworkGatewaySKUName) *armnetwork.VirtualNetworkGatewaySKUName {
+	return &s
+}
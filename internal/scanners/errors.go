@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ScanError - An error that prevented a scanner from completing in a subscription or resource group
+type ScanError struct {
+	SubscriptionID string
+	ResourceGroup  string
+	Scanner        string
+	Error          string
+}
+
+// ScanErrors - Collects the errors of a scan. It is safe for concurrent use. When FailFast
+// is set, the first error stops the scan instead.
+type ScanErrors struct {
+	FailFast bool
+	mu       sync.Mutex
+	errors   []ScanError
+}
+
+// Add - Records an error and returns it, or exits in fail fast mode. Errors caused by the
+// cancellation or timeout of the scan are ignored (nil is returned), since the report is then
+// marked as partial.
+func (e *ScanErrors) Add(subscriptionID, resourceGroup, scanner string, err error) *ScanError {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		log.Debug().Err(err).Msgf("%s stopped in subscription %s", scanner, subscriptionID)
+		return nil
+	}
+
+	location := "subscription " + subscriptionID
+	if resourceGroup != "" {
+		location += ", resource group " + resourceGroup
+	}
+
+	if e.FailFast {
+		log.Fatal().Err(err).Msgf("%s failed in %s", scanner, location)
+	}
+
+	log.Error().Err(err).Msgf("%s failed in %s. Continuing...", scanner, location)
+
+	scanError := ScanError{
+		SubscriptionID: subscriptionID,
+		ResourceGroup:  resourceGroup,
+		Scanner:        scanner,
+		Error:          err.Error(),
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, scanError)
+	return &scanError
+}
+
+// Restore - Records the errors of a previous run of a resumed scan
+func (e *ScanErrors) Restore(scanErrors []ScanError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, scanErrors...)
+}
+
+// Errors - Returns the recorded errors
+func (e *ScanErrors) Errors() []ScanError {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]ScanError{}, e.errors...)
+}
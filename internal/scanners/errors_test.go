@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestScanErrors_Add(t *testing.T) {
+	e := &ScanErrors{}
+
+	var wg sync.WaitGroup
+	for _, scanner := range []string{"kv", "st"} {
+		wg.Add(1)
+		go func(scanner string) {
+			defer wg.Done()
+			e.Add("sub1", "rg1", scanner, errors.New("forbidden"))
+		}(scanner)
+	}
+	wg.Wait()
+
+	got := e.Errors()
+	if len(got) != 2 {
+		t.Fatalf("ScanErrors.Errors() returned %d errors, want 2", len(got))
+	}
+
+	want := ScanError{SubscriptionID: "sub1", ResourceGroup: "rg1", Scanner: "kv", Error: "forbidden"}
+	if !reflect.DeepEqual(got[0], want) && !reflect.DeepEqual(got[1], want) {
+		t.Errorf("ScanErrors.Errors() = %v, want an entry %v", got, want)
+	}
+}
+
+func TestScanErrors_AddCanceled(t *testing.T) {
+	e := &ScanErrors{FailFast: true}
+	e.Add("sub1", "rg1", "kv", fmt.Errorf("listing vaults: %w", context.Canceled))
+	e.Add("sub1", "rg1", "st", context.DeadlineExceeded)
+
+	if got := e.Errors(); len(got) != 0 {
+		t.Errorf("ScanErrors.Errors() = %v, want no errors for a canceled scan", got)
+	}
+}
@@ -16,6 +16,17 @@ type EventGridScanner struct {
 	domainsClient *armeventgrid.DomainsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "evgd",
+		Description: "Azure Event Grid Domains",
+		ResourceTypes: []string{
+			"Microsoft.EventGrid/domains",
+		},
+		New: func() scanners.IAzureScanner { return &EventGridScanner{} },
+	})
+}
+
 // Init - Initializes the EventGridScanner
 func (a *EventGridScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,8 +52,9 @@ func (a *EventGridScanner) Scan(resourceGroupName string, scanContext *scanners.
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*d.ID),
 			ServiceName:    *d.Name,
+			ID:             *d.ID,
 			Type:           *d.Type,
 			Location:       *d.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (a *EventGridScanner) Scan(resourceGroupName string, scanContext *scanners.
 }
 
 func (a *EventGridScanner) listDomain(resourceGroupName string) ([]*armeventgrid.Domain, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armeventgrid.Domain](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.EventGrid/domains")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.domainsClient.NewListBySubscriptionPager(nil)
+
+		domains := make([]*armeventgrid.Domain, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			domains = append(domains, resp.Value...)
+		}
+		return domains, nil
+	}
+
 	pager := a.domainsClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	domains := make([]*armeventgrid.Domain, 0)
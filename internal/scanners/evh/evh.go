@@ -16,6 +16,17 @@ type EventHubScanner struct {
 	client *armeventhub.NamespacesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "evh",
+		Description: "Azure Event Hubs",
+		ResourceTypes: []string{
+			"Microsoft.EventHub/namespaces",
+		},
+		New: func() scanners.IAzureScanner { return &EventHubScanner{} },
+	})
+}
+
 // Init - Initializes the EventHubScanner
 func (a *EventHubScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,8 +52,9 @@ func (c *EventHubScanner) Scan(resourceGroupName string, scanContext *scanners.S
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*eventHub.ID),
 			ServiceName:    *eventHub.Name,
+			ID:             *eventHub.ID,
 			Type:           *eventHub.Type,
 			Location:       *eventHub.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *EventHubScanner) Scan(resourceGroupName string, scanContext *scanners.S
 }
 
 func (c *EventHubScanner) listEventHubs(resourceGroupName string) ([]*armeventhub.EHNamespace, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armeventhub.EHNamespace](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.EventHub/namespaces")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListPager(nil)
+
+		namespaces := make([]*armeventhub.EHNamespace, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, resp.Value...)
+		}
+		return namespaces, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	namespaces := make([]*armeventhub.EHNamespace, 0)
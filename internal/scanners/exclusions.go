@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Exclusions - Accepted findings loaded from a YAML or JSON file
+	Exclusions struct {
+		Exclusions []Exclusion `yaml:"exclusions" json:"exclusions"`
+	}
+
+	// Exclusion - Suppresses the rules matching every non empty field until it expires.
+	// RuleID, SubscriptionID, ResourceGroup, ResourceName and ResourceID accept * and ? wildcards.
+	Exclusion struct {
+		RuleID         string `yaml:"ruleId" json:"ruleId"`
+		SubscriptionID string `yaml:"subscriptionId" json:"subscriptionId"`
+		ResourceGroup  string `yaml:"resourceGroup" json:"resourceGroup"`
+		ResourceName   string `yaml:"resourceName" json:"resourceName"`
+		ResourceID     string `yaml:"resourceId" json:"resourceId"`
+		Expires        string `yaml:"expires" json:"expires"`
+		Justification  string `yaml:"justification" json:"justification"`
+
+		expires  time.Time
+		patterns []exclusionPattern
+	}
+
+	exclusionPattern struct {
+		field   func(r AzureServiceResult, ruleID string) string
+		pattern *regexp.Regexp
+	}
+)
+
+// ExclusionDateFormat - Layout of the expires field
+const ExclusionDateFormat = "2006-01-02"
+
+// LoadExclusions - Loads the exclusions from a YAML or JSON file
+func LoadExclusions(path string) (*Exclusions, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so a single decoder handles both formats.
+	e := &Exclusions{}
+	if err := yaml.Unmarshal(content, e); err != nil {
+		return nil, fmt.Errorf("failed to parse exclusions file %s: %w", path, err)
+	}
+
+	for i := range e.Exclusions {
+		if err := e.Exclusions[i].compile(); err != nil {
+			return nil, fmt.Errorf("invalid exclusion %d in %s: %w", i+1, path, err)
+		}
+	}
+
+	log.Info().Msgf("Loaded %d exclusions from %s", len(e.Exclusions), path)
+	return e, nil
+}
+
+func (x *Exclusion) compile() error {
+	if x.Expires != "" {
+		expires, err := time.Parse(ExclusionDateFormat, x.Expires)
+		if err != nil {
+			return fmt.Errorf("expires must use the %s format: %w", ExclusionDateFormat, err)
+		}
+		x.expires = expires
+	}
+
+	fields := []struct {
+		glob  string
+		field func(r AzureServiceResult, ruleID string) string
+	}{
+		{x.RuleID, func(r AzureServiceResult, ruleID string) string { return ruleID }},
+		{x.SubscriptionID, func(r AzureServiceResult, ruleID string) string { return r.SubscriptionID }},
+		{x.ResourceGroup, func(r AzureServiceResult, ruleID string) string { return r.ResourceGroup }},
+		{x.ResourceName, func(r AzureServiceResult, ruleID string) string { return r.ServiceName }},
+		{x.ResourceID, func(r AzureServiceResult, ruleID string) string { return r.ID }},
+	}
+
+	x.patterns = []exclusionPattern{}
+	for _, f := range fields {
+		if f.glob == "" {
+			continue
+		}
+		x.patterns = append(x.patterns, exclusionPattern{
+			field:   f.field,
+			pattern: globToRegexp(f.glob),
+		})
+	}
+
+	if len(x.patterns) == 0 {
+		return errors.New("at least one of ruleId, subscriptionId, resourceGroup, resourceName or resourceId is required")
+	}
+	return nil
+}
+
+// globToRegexp - Converts a case insensitive glob (* and ? wildcards) to a regular expression
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for _, c := range glob {
+		switch c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// isExpired - Exclusions expire at the end of the expires day
+func (x *Exclusion) isExpired(now time.Time) bool {
+	return !x.expires.IsZero() && !now.Before(x.expires.AddDate(0, 0, 1))
+}
+
+func (x *Exclusion) matches(r AzureServiceResult, ruleID string) bool {
+	for _, p := range x.patterns {
+		if !p.pattern.MatchString(p.field(r, ruleID)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply - Marks the broken rules matching an active exclusion as suppressed
+func (e *Exclusions) Apply(results []AzureServiceResult) {
+	if e == nil {
+		return
+	}
+	e.apply(results, time.Now())
+}
+
+func (e *Exclusions) apply(results []AzureServiceResult, now time.Time) {
+	for _, r := range results {
+		for k, rule := range r.Rules {
+			if !rule.IsBroken {
+				continue
+			}
+			for i := range e.Exclusions {
+				x := &e.Exclusions[i]
+				if !x.matches(r, rule.Id) {
+					continue
+				}
+				if x.isExpired(now) {
+					log.Warn().Msgf("Exclusion for %s on %s expired on %s", rule.Id, r.ServiceName, x.Expires)
+					continue
+				}
+				rule.IsBroken = false
+				rule.IsSuppressed = true
+				rule.Justification = x.Justification
+				r.Rules[k] = rule
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExclusions_apply(t *testing.T) {
+	yamlFile := `exclusions:
+  - ruleId: kv-006
+    resourceGroup: legacy-*
+    justification: Legacy vaults keep their names
+  - ruleId: st-*
+    resourceId: /subscriptions/*/providers/Microsoft.Storage/storageAccounts/accepted
+    expires: "2024-01-31"
+    justification: Accepted until migration
+`
+	jsonFile := `{"exclusions": [{"subscriptionId": "SUB1", "resourceName": "vault?", "justification": "Sandbox"}]}`
+
+	type want struct {
+		ruleID     string
+		broken     bool
+		suppressed bool
+	}
+	tests := []struct {
+		name    string
+		content string
+		result  AzureServiceResult
+		now     time.Time
+		want    []want
+	}{
+		{
+			name:    "rule and resource group glob",
+			content: yamlFile,
+			result:  newExclusionTestResult("sub1", "Legacy-RG", "vault1", "Microsoft.KeyVault/vaults", "kv-006", "kv-001"),
+			now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []want{{"kv-006", false, true}, {"kv-001", true, false}},
+		},
+		{
+			name:    "resource id glob before expiry",
+			content: yamlFile,
+			result:  newExclusionTestResult("sub1", "rg", "accepted", "Microsoft.Storage/storageAccounts", "st-001", "st-002"),
+			now:     time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC),
+			want:    []want{{"st-001", false, true}, {"st-002", false, true}},
+		},
+		{
+			name:    "expired",
+			content: yamlFile,
+			result:  newExclusionTestResult("sub1", "rg", "accepted", "Microsoft.Storage/storageAccounts", "st-001"),
+			now:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:    []want{{"st-001", true, false}},
+		},
+		{
+			name:    "json file",
+			content: jsonFile,
+			result:  newExclusionTestResult("sub1", "rg", "vault2", "Microsoft.KeyVault/vaults", "kv-001"),
+			now:     time.Now(),
+			want:    []want{{"kv-001", false, true}},
+		},
+		{
+			name:    "no match",
+			content: jsonFile,
+			result:  newExclusionTestResult("sub2", "rg", "vault2", "Microsoft.KeyVault/vaults", "kv-001"),
+			now:     time.Now(),
+			want:    []want{{"kv-001", true, false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "exclusions")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			e, err := LoadExclusions(path)
+			if err != nil {
+				t.Fatalf("LoadExclusions() error = %v", err)
+			}
+
+			e.apply([]AzureServiceResult{tt.result}, tt.now)
+
+			for _, w := range tt.want {
+				r := tt.result.Rules[w.ruleID]
+				if r.IsBroken != w.broken || r.IsSuppressed != w.suppressed {
+					t.Errorf("rule %s: broken = %v, suppressed = %v, want %v, %v", w.ruleID, r.IsBroken, r.IsSuppressed, w.broken, w.suppressed)
+				}
+				if r.IsSuppressed && r.Justification == "" {
+					t.Errorf("rule %s: missing justification", w.ruleID)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadExclusions_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no matcher", `exclusions: [{justification: "everything"}]`},
+		{"bad date", `exclusions: [{ruleId: kv-001, expires: 31/01/2024}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "exclusions.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadExclusions(path); err == nil {
+				t.Errorf("LoadExclusions() expected an error")
+			}
+		})
+	}
+}
+
+func newExclusionTestResult(subscriptionID, resourceGroup, name, resourceType string, ruleIDs ...string) AzureServiceResult {
+	r := AzureServiceResult{
+		SubscriptionID: subscriptionID,
+		ResourceGroup:  resourceGroup,
+		ServiceName:    name,
+		Type:           resourceType,
+		ID:             "/subscriptions/" + subscriptionID + "/resourceGroups/" + resourceGroup + "/providers/" + resourceType + "/" + name,
+		Rules:          map[string]AzureRuleResult{},
+	}
+	for _, id := range ruleIDs {
+		r.Rules[id] = AzureRuleResult{Id: id, IsBroken: true}
+	}
+	return r
+}
@@ -0,0 +1,649 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression - A compiled custom rule expression.
+//
+// Expressions combine JSONPath operands evaluated against the ARM JSON of a resource
+// ($.location, $.properties.sku.name, $.tags['cost-center'], $.properties.subnets[*].name)
+// with literals ('text', 42, true, null, ['a', 'b']), the comparison operators
+// ==, !=, <, <=, >, >=, in, contains, matches, startsWith and endsWith, the logical
+// operators &&, || and !, parentheses, and the functions exists(path), size(value) and
+// lower(value).
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+type (
+	exprNode interface {
+		eval(doc interface{}) interface{}
+	}
+
+	exprLiteral struct {
+		value interface{}
+	}
+
+	exprList struct {
+		items []exprNode
+	}
+
+	exprPath struct {
+		segments []pathSegment
+		wildcard bool
+	}
+
+	pathSegment struct {
+		key      string
+		index    int
+		isIndex  bool
+		wildcard bool
+	}
+
+	exprNot struct {
+		operand exprNode
+	}
+
+	exprLogical struct {
+		op          string
+		left, right exprNode
+	}
+
+	exprCompare struct {
+		op          string
+		left, right exprNode
+		pattern     *regexp.Regexp
+	}
+
+	exprCall struct {
+		name string
+		args []exprNode
+	}
+
+	exprToken struct {
+		kind  string // path, string, number, ident, op, eof
+		text  string
+		value interface{}
+		pos   int
+	}
+
+	exprParser struct {
+		tokens []exprToken
+		pos    int
+	}
+)
+
+// CompileExpression - Parses a custom rule expression
+func CompileExpression(source string) (*Expression, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != "eof" {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// Eval - Evaluates the expression against a JSON document decoded into interface{} values
+func (e *Expression) Eval(doc interface{}) interface{} {
+	return e.root.eval(doc)
+}
+
+// EvalBool - Evaluates the expression and returns true only for a boolean true result
+func (e *Expression) EvalBool(doc interface{}) bool {
+	b, ok := e.Eval(doc).(bool)
+	return ok && b
+}
+
+// String - Returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+func tokenizeExpression(source string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	runes := []rune(source)
+	i := 0
+	for i < len(runes) {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '$':
+			start := i
+			i++
+			for i < len(runes) {
+				if runes[i] == '.' {
+					i++
+					for i < len(runes) && isPathChar(runes[i]) {
+						i++
+					}
+				} else if runes[i] == '[' {
+					end, err := skipBracket(runes, i)
+					if err != nil {
+						return nil, err
+					}
+					i = end
+				} else {
+					break
+				}
+			}
+			tokens = append(tokens, exprToken{kind: "path", text: string(runes[start:i]), pos: start})
+		case c == '\'' || c == '"':
+			s, end, err := readQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, exprToken{kind: "string", text: string(runes[i:end]), value: s, pos: i})
+			i = end
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			f, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number at position %d", start)
+			}
+			tokens = append(tokens, exprToken{kind: "number", text: string(runes[start:i]), value: f, pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: "ident", text: string(runes[start:i]), pos: start})
+		default:
+			op := ""
+			if i+1 < len(runes) {
+				switch string(runes[i : i+2]) {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					op = string(runes[i : i+2])
+				}
+			}
+			if op == "" && strings.ContainsRune("<>!()[],", c) {
+				op = string(c)
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, exprToken{kind: "op", text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, exprToken{kind: "eof", text: "end of expression", pos: len(runes)}), nil
+}
+
+func isPathChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == '@'
+}
+
+func skipBracket(runes []rune, i int) (int, error) {
+	i++
+	for i < len(runes) && runes[i] != ']' {
+		if runes[i] == '\'' || runes[i] == '"' {
+			_, end, err := readQuoted(runes, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		}
+		i++
+	}
+	if i >= len(runes) {
+		return 0, fmt.Errorf("unterminated [ in path")
+	}
+	return i + 1, nil
+}
+
+func readQuoted(runes []rune, i int) (string, int, error) {
+	quote := runes[i]
+	var b strings.Builder
+	for j := i + 1; j < len(runes); j++ {
+		switch runes[j] {
+		case '\\':
+			if j+1 < len(runes) {
+				j++
+				b.WriteRune(runes[j])
+			}
+		case quote:
+			return b.String(), j + 1, nil
+		default:
+			b.WriteRune(runes[j])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at position %d", i)
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != "eof" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) expect(op string) error {
+	if t := p.next(); t.kind != "op" || t.text != op {
+		return fmt.Errorf("expected %q at position %d, found %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprLogical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprLogical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if t := p.peek(); t.kind == "op" && t.text == "!" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNot{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	op := ""
+	switch {
+	case t.kind == "op" && (t.text == "==" || t.text == "!=" || t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+		op = t.text
+	case t.kind == "ident" && (t.text == "in" || t.text == "contains" || t.text == "matches" || t.text == "startsWith" || t.text == "endsWith"):
+		op = t.text
+	default:
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &exprCompare{op: op, left: left, right: right}
+	if op == "matches" {
+		if l, ok := right.(*exprLiteral); ok {
+			s, ok := l.value.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches requires a string pattern at position %d", t.pos)
+			}
+			c.pattern, err = regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", s, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case "path":
+		return parsePath(t.text)
+	case "string", "number":
+		return &exprLiteral{value: t.value}, nil
+	case "ident":
+		switch t.text {
+		case "true":
+			return &exprLiteral{value: true}, nil
+		case "false":
+			return &exprLiteral{value: false}, nil
+		case "null":
+			return &exprLiteral{value: nil}, nil
+		case "exists", "size", "lower":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			if _, ok := arg.(*exprPath); t.text == "exists" && !ok {
+				return nil, fmt.Errorf("exists requires a path at position %d", t.pos)
+			}
+			return &exprCall{name: t.text, args: []exprNode{arg}}, nil
+		}
+	case "op":
+		switch t.text {
+		case "(":
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		case "[":
+			list := &exprList{items: []exprNode{}}
+			if n := p.peek(); n.kind == "op" && n.text == "]" {
+				p.next()
+				return list, nil
+			}
+			for {
+				item, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				n := p.next()
+				if n.kind == "op" && n.text == "]" {
+					return list, nil
+				}
+				if n.kind != "op" || n.text != "," {
+					return nil, fmt.Errorf("expected , or ] at position %d", n.pos)
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func parsePath(text string) (*exprPath, error) {
+	path := &exprPath{segments: []pathSegment{}}
+	runes := []rune(text)
+	i := 1
+	for i < len(runes) {
+		switch runes[i] {
+		case '.':
+			start := i + 1
+			i = start
+			for i < len(runes) && isPathChar(runes[i]) {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("empty segment in path %s", text)
+			}
+			path.segments = append(path.segments, pathSegment{key: string(runes[start:i])})
+		case '[':
+			end, err := skipBracket(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			inner := strings.TrimSpace(string(runes[i+1 : end-1]))
+			i = end
+			switch {
+			case inner == "*":
+				path.segments = append(path.segments, pathSegment{wildcard: true})
+				path.wildcard = true
+			case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, "\""):
+				key, _, err := readQuoted([]rune(inner), 0)
+				if err != nil {
+					return nil, err
+				}
+				path.segments = append(path.segments, pathSegment{key: key})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q in path %s", inner, text)
+				}
+				path.segments = append(path.segments, pathSegment{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("invalid path %s", text)
+		}
+	}
+	return path, nil
+}
+
+func (e *exprLiteral) eval(doc interface{}) interface{} {
+	return e.value
+}
+
+func (e *exprList) eval(doc interface{}) interface{} {
+	values := make([]interface{}, 0, len(e.items))
+	for _, item := range e.items {
+		values = append(values, item.eval(doc))
+	}
+	return values
+}
+
+// eval - Returns the value at the path, nil when it does not exist, or the
+// list of matching values when the path contains a [*] wildcard
+func (e *exprPath) eval(doc interface{}) interface{} {
+	current := []interface{}{doc}
+	for _, s := range e.segments {
+		next := []interface{}{}
+		for _, c := range current {
+			switch v := c.(type) {
+			case map[string]interface{}:
+				if s.wildcard {
+					for _, item := range v {
+						next = append(next, item)
+					}
+				} else if item, ok := v[s.key]; ok && !s.isIndex {
+					next = append(next, item)
+				}
+			case []interface{}:
+				if s.wildcard {
+					next = append(next, v...)
+				} else if s.isIndex && s.index >= 0 && s.index < len(v) {
+					next = append(next, v[s.index])
+				}
+			}
+		}
+		current = next
+	}
+
+	if e.wildcard {
+		return current
+	}
+	if len(current) == 0 {
+		return nil
+	}
+	return current[0]
+}
+
+func (e *exprNot) eval(doc interface{}) interface{} {
+	b, ok := e.operand.eval(doc).(bool)
+	return ok && !b
+}
+
+func (e *exprLogical) eval(doc interface{}) interface{} {
+	left, _ := e.left.eval(doc).(bool)
+	if e.op == "&&" && !left {
+		return false
+	}
+	if e.op == "||" && left {
+		return true
+	}
+	right, _ := e.right.eval(doc).(bool)
+	return right
+}
+
+func (e *exprCall) eval(doc interface{}) interface{} {
+	v := e.args[0].eval(doc)
+	switch e.name {
+	case "exists":
+		if list, ok := v.([]interface{}); ok && e.args[0].(*exprPath).wildcard {
+			return len(list) > 0
+		}
+		return v != nil
+	case "size":
+		switch t := v.(type) {
+		case []interface{}:
+			return float64(len(t))
+		case map[string]interface{}:
+			return float64(len(t))
+		case string:
+			return float64(len(t))
+		}
+		return float64(0)
+	case "lower":
+		switch t := v.(type) {
+		case string:
+			return strings.ToLower(t)
+		case []interface{}:
+			values := make([]interface{}, 0, len(t))
+			for _, item := range t {
+				if s, ok := item.(string); ok {
+					item = strings.ToLower(s)
+				}
+				values = append(values, item)
+			}
+			return values
+		}
+		return v
+	}
+	return nil
+}
+
+func (e *exprCompare) eval(doc interface{}) interface{} {
+	left := e.left.eval(doc)
+	right := e.right.eval(doc)
+
+	switch e.op {
+	case "==":
+		return exprEqual(left, right)
+	case "!=":
+		return !exprEqual(left, right)
+	case "<", "<=", ">", ">=":
+		l, lok := left.(float64)
+		r, rok := right.(float64)
+		if !lok || !rok {
+			return false
+		}
+		switch e.op {
+		case "<":
+			return l < r
+		case "<=":
+			return l <= r
+		case ">":
+			return l > r
+		}
+		return l >= r
+	case "in":
+		list, ok := right.([]interface{})
+		if !ok {
+			return false
+		}
+		// A list on the left (e.g. a [*] path) is in the right list when all its items are.
+		if items, ok := left.([]interface{}); ok {
+			for _, item := range items {
+				if !exprContains(list, item) {
+					return false
+				}
+			}
+			return true
+		}
+		return exprContains(list, left)
+	case "contains":
+		switch l := left.(type) {
+		case []interface{}:
+			return exprContains(l, right)
+		case map[string]interface{}:
+			key, ok := right.(string)
+			if !ok {
+				return false
+			}
+			_, exists := l[key]
+			return exists
+		case string:
+			r, ok := right.(string)
+			return ok && strings.Contains(l, r)
+		}
+		return false
+	case "startsWith", "endsWith":
+		l, lok := left.(string)
+		r, rok := right.(string)
+		if !lok || !rok {
+			return false
+		}
+		if e.op == "startsWith" {
+			return strings.HasPrefix(l, r)
+		}
+		return strings.HasSuffix(l, r)
+	case "matches":
+		l, ok := left.(string)
+		if !ok {
+			return false
+		}
+		pattern := e.pattern
+		if pattern == nil {
+			r, ok := right.(string)
+			if !ok {
+				return false
+			}
+			var err error
+			if pattern, err = regexp.Compile(r); err != nil {
+				return false
+			}
+		}
+		return pattern.MatchString(l)
+	}
+	return false
+}
+
+func exprEqual(left, right interface{}) bool {
+	return reflect.DeepEqual(left, right)
+}
+
+func exprContains(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if exprEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testExpressionDocument() map[string]interface{} {
+	return map[string]interface{}{
+		"location": "westeurope",
+		"zones":    []interface{}{"1", "2", "3"},
+		"tags":     map[string]interface{}{"cost-center": "1234", "env": "prod", "a.b": "dotted"},
+		"sku":      map[string]interface{}{"name": "Standard", "capacity": float64(2)},
+		"properties": map[string]interface{}{
+			"enabled":  true,
+			"ratio":    float64(0.75),
+			"offset":   float64(-1),
+			"pattern":  "^west",
+			"invalid":  "[",
+			"nothing":  nil,
+			"features": []interface{}{"IPv6", "Http2"},
+			"subnets": []interface{}{
+				map[string]interface{}{"name": "snet-a"},
+				map[string]interface{}{"name": "snet-b"},
+			},
+		},
+	}
+}
+
+func TestExpression_Operators(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"$.location == 'westeurope'", true},
+		{"$.location == \"westeurope\"", true},
+		{"$.location == 'West Europe'", false},
+		{"$.sku.capacity == 2", true},
+		{"$.properties.ratio == 0.75", true},
+		{"$.properties.offset == -1", true},
+		{"$.properties.enabled == true", true},
+		{"$.properties.enabled == false", false},
+		{"$.properties.nothing == null", true},
+		{"$.zones == ['1', '2', '3']", true},
+		{"$.zones == ['1', '2']", false},
+		{"$.location != 'eastus'", true},
+		{"$.location != 'westeurope'", false},
+		{"$.sku.capacity < 3", true},
+		{"$.sku.capacity < 2", false},
+		{"$.sku.capacity <= 2", true},
+		{"$.sku.capacity > 1", true},
+		{"$.sku.capacity > 2", false},
+		{"$.sku.capacity >= 2", true},
+		{"$.location in ['northeurope', 'westeurope']", true},
+		{"$.location in []", false},
+		{"$.zones[*] in ['1', '2', '3', '4']", true},
+		{"$.zones[*] in ['1', '2']", false},
+		{"$.zones contains '2'", true},
+		{"$.zones contains '4'", false},
+		{"$.tags contains 'env'", true},
+		{"$.tags contains 'owner'", false},
+		{"$.tags[*] contains 'prod'", true},
+		{"$.location contains 'europe'", true},
+		{"$.location contains 'asia'", false},
+		{"$.location matches '^west(europe|us)$'", true},
+		{"$.location matches '^east'", false},
+		{"$.location matches $.properties.pattern", true},
+		{"$.location startsWith 'west'", true},
+		{"$.location startsWith 'europe'", false},
+		{"$.location endsWith 'europe'", true},
+		{"$.location endsWith 'west'", false},
+		{"$.location == 'westeurope' && $.sku.name == 'Standard'", true},
+		{"$.location == 'westeurope' && $.sku.name == 'Premium'", false},
+		{"$.location == 'eastus' || $.sku.name == 'Standard'", true},
+		{"$.location == 'eastus' || $.sku.name == 'Premium'", false},
+		{"!($.location == 'eastus')", true},
+		{"!$.properties.enabled", false},
+		{"exists($.tags.env)", true},
+		{"exists($.properties.nothing)", false},
+		{"exists($.properties.subnets[*].name)", true},
+		{"size($.zones) == 3", true},
+		{"size($.tags) == 3", true},
+		{"size($.location) == 10", true},
+		{"lower($.sku.name) == 'standard'", true},
+		{"lower($.properties.features) contains 'ipv6'", true},
+		{"$.tags['cost-center'] == '1234'", true},
+		{"$.tags['a.b'] == 'dotted'", true},
+		{"$.tags[\"a.b\"] == 'dotted'", true},
+		{"$.properties.subnets[1].name == 'snet-b'", true},
+		{"$.properties.subnets[*].name == ['snet-a', 'snet-b']", true},
+		{"'it\\'s' == \"it's\"", true},
+	}
+	doc := testExpressionDocument()
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.EvalBool(doc); got != tt.want {
+				t.Errorf("Expression.EvalBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_Precedence(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		// && binds tighter than ||
+		{"true || false && false", true},
+		{"false && true || true", true},
+		{"(true || false) && false", false},
+		// ! applies to the comparison that follows it
+		{"!$.location == 'eastus'", true},
+		{"!true && false", false},
+		{"!(true && false)", true},
+		{"!!true", true},
+		// Operators are evaluated left to right
+		{"false || false || true", true},
+		{"true && true && false", false},
+		{"($.location == 'eastus' || $.location == 'westeurope') && $.sku.capacity >= 2", true},
+		// Right operands are not evaluated when the left operand decides the result
+		{"true || $.location matches $.properties.invalid", true},
+		{"false && $.location matches $.properties.invalid", false},
+	}
+	doc := testExpressionDocument()
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.EvalBool(doc); got != tt.want {
+				t.Errorf("Expression.EvalBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_MissingPaths(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"$.missing == null", true},
+		{"$.missing != null", false},
+		{"$.missing != 'x'", true},
+		{"$.missing.value == null", true},
+		{"$.location.value == null", true},
+		{"$.tags[0] == null", true},
+		{"$.zones[5] == null", true},
+		{"$.zones[-1] == null", true},
+		{"$.zones.first == null", true},
+		{"exists($.missing)", false},
+		{"!exists($.missing)", true},
+		{"exists($.properties.subnets[*].missing)", false},
+		{"$.missing > 0", false},
+		{"$.missing <= 0", false},
+		{"$.missing in ['a']", false},
+		{"$.missing contains 'a'", false},
+		{"$.missing matches '.*'", false},
+		{"$.missing startsWith ''", false},
+		{"size($.missing) == 0", true},
+		{"lower($.missing) == null", true},
+		{"!$.missing", false},
+		// A [*] path without any value is in every list
+		{"$.missing[*].name in ['a']", true},
+		{"$.missing[*].name == []", true},
+	}
+	doc := testExpressionDocument()
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.EvalBool(doc); got != tt.want {
+				t.Errorf("Expression.EvalBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_TypeMismatches(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"$.sku.capacity == '2'", false},
+		{"$.sku.capacity != '2'", true},
+		{"$.properties.enabled == 'true'", false},
+		{"$.zones contains 2", false},
+		{"$.sku.name > 1", false},
+		{"$.sku.name <= 'Z'", false},
+		{"$.sku.capacity startsWith '2'", false},
+		{"$.sku.capacity endsWith '2'", false},
+		{"$.sku.capacity matches '2'", false},
+		{"$.location matches $.sku.capacity", false},
+		{"$.location matches $.properties.invalid", false},
+		{"$.location contains 1", false},
+		{"$.tags contains 1", false},
+		{"$.sku.capacity contains 2", false},
+		{"$.location in 'westeurope'", false},
+		{"$.location in $.tags", false},
+		{"!$.location", false},
+		{"$.location && true", false},
+		{"$.location || true", true},
+		{"size($.sku.capacity) == 0", true},
+		{"lower($.sku.capacity) == 2", true},
+		{"$.location", false},
+		{"$.sku.capacity", false},
+	}
+	doc := testExpressionDocument()
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.EvalBool(doc); got != tt.want {
+				t.Errorf("Expression.EvalBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_Eval(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       interface{}
+	}{
+		{"$.location", "westeurope"},
+		{"$.sku", map[string]interface{}{"name": "Standard", "capacity": float64(2)}},
+		{"$.missing", nil},
+		{"$.properties.subnets[*].name", []interface{}{"snet-a", "snet-b"}},
+		{"$.missing[*].name", []interface{}{}},
+		{"size($.zones)", float64(3)},
+		{"lower($.sku.name)", "standard"},
+		{"['a', 1, true, null]", []interface{}{"a", float64(1), true, nil}},
+		{"$.sku.capacity > 1", true},
+	}
+	doc := testExpressionDocument()
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := CompileExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileExpression() error = %v", err)
+			}
+			if got := e.Eval(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expression.Eval() = %v, want %v", got, tt.want)
+			}
+			if e.String() != tt.expression {
+				t.Errorf("Expression.String() = %s, want %s", e.String(), tt.expression)
+			}
+		})
+	}
+}
+
+func TestCompileExpression_Malformed(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{"", "unexpected \"end of expression\" at position 0"},
+		{"$.location ==", "unexpected \"end of expression\" at position 13"},
+		{"$.location == 'west", "unterminated string at position 14"},
+		{"$.tags['env", "unterminated string at position 7"},
+		{"$.zones[0", "unterminated [ in path"},
+		{"$.zones[x]", "invalid index \"x\" in path $.zones[x]"},
+		{"$..location", "empty segment in path $..location"},
+		{"$.location = 'westeurope'", "unexpected character '=' at position 11"},
+		{"$.location == 'a' & $.sku.name == 'b'", "unexpected character '&' at position 18"},
+		{"1.2.3 == 1", "invalid number at position 0"},
+		{"$.sku.capacity == 1 == true", "unexpected \"==\" at position 20"},
+		{"$.location == 'a' $.sku.name", "unexpected \"$.sku.name\" at position 18"},
+		{"($.location == 'a'", "expected \")\" at position 18"},
+		{"$.location == 'a')", "unexpected \")\" at position 17"},
+		{"$.location in ['a', 'b'", "expected , or ] at position 23"},
+		{"$.location in ['a' 'b']", "expected , or ] at position 19"},
+		{"exists('x')", "exists requires a path at position 0"},
+		{"exists($.location", "expected \")\" at position 17"},
+		{"size()", "unexpected \")\" at position 5"},
+		{"upper($.location) == 'A'", "unexpected \"upper\" at position 0"},
+		{"$.location matches '['", "invalid pattern \"[\""},
+		{"$.location matches 1", "matches requires a string pattern at position 11"},
+		{"&& true", "unexpected \"&&\" at position 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := CompileExpression(tt.expression)
+			if err == nil {
+				t.Fatalf("CompileExpression() expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CompileExpression() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RuleFilter - Selects the rules evaluated by the RuleEngine. Empty fields select every rule.
+type RuleFilter struct {
+	rules       []*regexp.Regexp
+	skipRules   []*regexp.Regexp
+	categories  map[string]bool
+	minSeverity string
+}
+
+// NewRuleFilter - Creates a RuleFilter. Rule ids accept * and ? wildcards, categories
+// and severities are case insensitive.
+func NewRuleFilter(rules, skipRules, categories []string, minSeverity string) (*RuleFilter, error) {
+	f := &RuleFilter{
+		rules:      globsToRegexps(rules),
+		skipRules:  globsToRegexps(skipRules),
+		categories: map[string]bool{},
+	}
+
+	known := []string{
+		RulesCategoryReliability,
+		RulesCategorySecurity,
+		RulesCategoryCostOptimization,
+		RulesCategoryOperationalExcellence,
+		RulesCategoryPerformanceEfficienccy,
+	}
+	for _, c := range categories {
+		found := false
+		for _, k := range known {
+			if strings.EqualFold(c, k) {
+				f.categories[k] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported category: %s (use %s)", c, strings.Join(known, ", "))
+		}
+	}
+
+	if minSeverity != "" {
+		severity, err := ParseSeverity(minSeverity)
+		if err != nil {
+			return nil, err
+		}
+		f.minSeverity = severity
+	}
+
+	return f, nil
+}
+
+func globsToRegexps(globs []string) []*regexp.Regexp {
+	regexps := []*regexp.Regexp{}
+	for _, g := range globs {
+		if g = strings.TrimSpace(g); g != "" {
+			regexps = append(regexps, globToRegexp(g))
+		}
+	}
+	return regexps
+}
+
+func matchesAny(regexps []*regexp.Regexp, s string) bool {
+	for _, r := range regexps {
+		if r.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches - Returns true if the rule should be evaluated. A nil filter matches every rule.
+func (f *RuleFilter) Matches(rule AzureRule) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.rules) > 0 && !matchesAny(f.rules, rule.Id) {
+		return false
+	}
+	if matchesAny(f.skipRules, rule.Id) {
+		return false
+	}
+	if len(f.categories) > 0 && !f.categories[rule.Category] {
+		return false
+	}
+	if f.minSeverity != "" && !SeverityAtLeast(rule.Severity, f.minSeverity) {
+		return false
+	}
+	return true
+}
+
+// MatchesAny - Returns true if at least one of the rules should be evaluated
+func (f *RuleFilter) MatchesAny(rules map[string]AzureRule) bool {
+	for _, r := range rules {
+		if f.Matches(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"testing"
+)
+
+func TestRuleFilter_Matches(t *testing.T) {
+	kv001 := AzureRule{Id: "kv-001", Category: RulesCategoryReliability, Severity: SeverityMedium}
+	kv004 := AzureRule{Id: "kv-004", Category: RulesCategorySecurity, Severity: SeverityHigh}
+	st001 := AzureRule{Id: "st-001", Category: RulesCategorySecurity, Severity: SeverityLow}
+
+	tests := []struct {
+		name        string
+		rules       []string
+		skipRules   []string
+		categories  []string
+		minSeverity string
+		want        []bool
+	}{
+		{"no filter", nil, nil, nil, "", []bool{true, true, true}},
+		{"rules", []string{"kv-*"}, nil, nil, "", []bool{true, true, false}},
+		{"skip rules", nil, []string{"KV-001"}, nil, "", []bool{false, true, true}},
+		{"categories", nil, nil, []string{"security"}, "", []bool{false, true, true}},
+		{"min severity", nil, nil, nil, "Medium", []bool{true, true, false}},
+		{"combined", []string{"kv-*"}, nil, []string{"Security"}, "High", []bool{false, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewRuleFilter(tt.rules, tt.skipRules, tt.categories, tt.minSeverity)
+			if err != nil {
+				t.Fatalf("NewRuleFilter() error = %v", err)
+			}
+			for i, r := range []AzureRule{kv001, kv004, st001} {
+				if got := f.Matches(r); got != tt.want[i] {
+					t.Errorf("RuleFilter.Matches(%s) = %v, want %v", r.Id, got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := NewRuleFilter(nil, nil, []string{"Speed"}, ""); err == nil {
+		t.Errorf("NewRuleFilter() expected an error for an unknown category")
+	}
+
+	var f *RuleFilter
+	if !f.Matches(kv001) {
+		t.Errorf("nil RuleFilter should match every rule")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+type (
+	// VirtualNetworkGateways - Caches the Virtual Network Gateways of a subscription by resource group,
+	// so the ExpressRoute and VPN Gateway scanners, that share their resource type, list them once
+	VirtualNetworkGateways struct {
+		mu       sync.Mutex
+		listings map[string]*gatewayListing
+	}
+
+	gatewayListing struct {
+		once     sync.Once
+		gateways []*armnetwork.VirtualNetworkGateway
+		err      error
+	}
+)
+
+// ListVirtualNetworkGateways - Returns the Virtual Network Gateways of a given type in a resource group,
+// or in the subscription when resourceGroupName is empty. The gateways of each resource group are listed
+// once when the scan context has a VirtualNetworkGateways cache.
+func ListVirtualNetworkGateways(config *ScannerConfig, scanContext *ScanContext, resourceGroupName string, gatewayType armnetwork.VirtualNetworkGatewayType) ([]*armnetwork.VirtualNetworkGateway, error) {
+	var gateways []*armnetwork.VirtualNetworkGateway
+	var err error
+	if scanContext != nil && scanContext.VirtualNetworkGateways != nil {
+		gateways, err = scanContext.VirtualNetworkGateways.list(config, resourceGroupName)
+	} else {
+		gateways, err = listVirtualNetworkGateways(config, resourceGroupName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*armnetwork.VirtualNetworkGateway, 0)
+	for _, g := range gateways {
+		if g.Properties != nil && g.Properties.GatewayType != nil && *g.Properties.GatewayType == gatewayType {
+			res = append(res, g)
+		}
+	}
+	return res, nil
+}
+
+func (c *VirtualNetworkGateways) list(config *ScannerConfig, resourceGroupName string) ([]*armnetwork.VirtualNetworkGateway, error) {
+	c.mu.Lock()
+	if c.listings == nil {
+		c.listings = map[string]*gatewayListing{}
+	}
+	key := strings.ToLower(resourceGroupName)
+	l, ok := c.listings[key]
+	if !ok {
+		l = &gatewayListing{}
+		c.listings[key] = l
+	}
+	c.mu.Unlock()
+
+	l.once.Do(func() {
+		l.gateways, l.err = listVirtualNetworkGateways(config, resourceGroupName)
+	})
+	return l.gateways, l.err
+}
+
+// listVirtualNetworkGateways - Gateways can only be listed by resource group, so the subscription
+// is scanned through the groups that contain them.
+func listVirtualNetworkGateways(config *ScannerConfig, resourceGroupName string) ([]*armnetwork.VirtualNetworkGateway, error) {
+	if config.Snapshot != nil {
+		return ListFromSnapshot[armnetwork.VirtualNetworkGateway](config.Snapshot, config.SubscriptionID, resourceGroupName, "Microsoft.Network/virtualNetworkGateways")
+	}
+
+	resourceGroups := []string{resourceGroupName}
+	if resourceGroupName == "" {
+		inventory := ResourceInventoryScanner{}
+		if err := inventory.Init(config); err != nil {
+			return nil, err
+		}
+		var err error
+		resourceGroups, err = inventory.ListResourceGroupsWithType("Microsoft.Network/virtualNetworkGateways")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := armnetwork.NewVirtualNetworkGatewaysClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	gateways := make([]*armnetwork.VirtualNetworkGateway, 0)
+	for _, rg := range resourceGroups {
+		pager := client.NewListPager(rg, nil)
+		for pager.More() {
+			resp, err := pager.NextPage(config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			gateways = append(gateways, resp.Value...)
+		}
+	}
+	return gateways, nil
+}
+
+// GetGatewaySKU - Returns the SKU name of a Virtual Network Gateway, or an empty name when it is not set
+func GetGatewaySKU(g *armnetwork.VirtualNetworkGateway) armnetwork.VirtualNetworkGatewaySKUName {
+	if g.Properties == nil || g.Properties.SKU == nil || g.Properties.SKU.Name == nil {
+		return ""
+	}
+	return *g.Properties.SKU.Name
+}
+
+// IsZoneRedundantGateway - Returns true for the AZ SKUs, such as VpnGw2AZ or ErGw1AZ, and for ErGwScale,
+// which is zone-redundant by default
+func IsZoneRedundantGateway(g *armnetwork.VirtualNetworkGateway) bool {
+	sku := string(GetGatewaySKU(g))
+	return strings.HasSuffix(sku, "AZ") || sku == "ErGwScale"
+}
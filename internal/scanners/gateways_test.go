@@ -0,0 +1,125 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+const snapshotGateways = `{"value": [
+	{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworkGateways/ergw-1", "name": "ergw-1", "type": "Microsoft.Network/virtualNetworkGateways", "properties": {"gatewayType": "ExpressRoute"}},
+	{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworkGateways/vpng-1", "name": "vpng-1", "type": "Microsoft.Network/virtualNetworkGateways", "properties": {"gatewayType": "Vpn"}},
+	{"id": "/subscriptions/sub1/resourceGroups/RG2/providers/Microsoft.Network/virtualNetworkGateways/vpng-2", "name": "vpng-2", "type": "Microsoft.Network/virtualNetworkGateways", "properties": {"gatewayType": "Vpn"}}
+]}`
+
+func loadGatewaySnapshot(t *testing.T, content string) *Snapshot {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "gateways.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	return s
+}
+
+func TestListVirtualNetworkGateways(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceGroup string
+		gatewayType   armnetwork.VirtualNetworkGatewayType
+		want          []string
+	}{
+		{"subscription ExpressRoute", "", armnetwork.VirtualNetworkGatewayTypeExpressRoute, []string{"ergw-1"}},
+		{"subscription VPN", "", armnetwork.VirtualNetworkGatewayTypeVPN, []string{"vpng-1", "vpng-2"}},
+		{"resource group VPN", "rg2", armnetwork.VirtualNetworkGatewayTypeVPN, []string{"vpng-2"}},
+		{"resource group without gateways", "rg3", armnetwork.VirtualNetworkGatewayTypeVPN, []string{}},
+	}
+	config := &ScannerConfig{SubscriptionID: "sub1", Snapshot: loadGatewaySnapshot(t, snapshotGateways)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, scanContext := range []*ScanContext{nil, {}, {VirtualNetworkGateways: &VirtualNetworkGateways{}}} {
+				gateways, err := ListVirtualNetworkGateways(config, scanContext, tt.resourceGroup, tt.gatewayType)
+				if err != nil {
+					t.Fatalf("ListVirtualNetworkGateways() error = %v", err)
+				}
+				got := []string{}
+				for _, g := range gateways {
+					got = append(got, *g.Name)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListVirtualNetworkGateways() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListVirtualNetworkGateways_Cache(t *testing.T) {
+	config := &ScannerConfig{SubscriptionID: "sub1", Snapshot: loadGatewaySnapshot(t, snapshotGateways)}
+	scanContext := &ScanContext{VirtualNetworkGateways: &VirtualNetworkGateways{}}
+
+	if _, err := ListVirtualNetworkGateways(config, scanContext, "RG1", armnetwork.VirtualNetworkGatewayTypeExpressRoute); err != nil {
+		t.Fatalf("ListVirtualNetworkGateways() error = %v", err)
+	}
+
+	// The second scanner gets the gateways of the first listing
+	config.Snapshot = loadGatewaySnapshot(t, `{"value": []}`)
+	gateways, err := ListVirtualNetworkGateways(config, scanContext, "rg1", armnetwork.VirtualNetworkGatewayTypeVPN)
+	if err != nil {
+		t.Fatalf("ListVirtualNetworkGateways() error = %v", err)
+	}
+	if len(gateways) != 1 || *gateways[0].Name != "vpng-1" {
+		t.Errorf("ListVirtualNetworkGateways() = %v, want the cached vpng-1", gateways)
+	}
+
+	// Other resource groups are listed
+	gateways, err = ListVirtualNetworkGateways(config, scanContext, "rg2", armnetwork.VirtualNetworkGatewayTypeVPN)
+	if err != nil {
+		t.Fatalf("ListVirtualNetworkGateways() error = %v", err)
+	}
+	if len(gateways) != 0 {
+		t.Errorf("ListVirtualNetworkGateways() = %v, want no gateway", gateways)
+	}
+}
+
+func TestIsZoneRedundantGateway(t *testing.T) {
+	tests := []struct {
+		name    string
+		gateway *armnetwork.VirtualNetworkGateway
+		wantSKU armnetwork.VirtualNetworkGatewaySKUName
+		want    bool
+	}{
+		{"no properties", &armnetwork.VirtualNetworkGateway{}, "", false},
+		{"VPN AZ SKU", gatewayWithSKU(armnetwork.VirtualNetworkGatewaySKUNameVPNGw2AZ), armnetwork.VirtualNetworkGatewaySKUNameVPNGw2AZ, true},
+		{"VPN SKU", gatewayWithSKU(armnetwork.VirtualNetworkGatewaySKUNameVPNGw2), armnetwork.VirtualNetworkGatewaySKUNameVPNGw2, false},
+		{"ExpressRoute AZ SKU", gatewayWithSKU(armnetwork.VirtualNetworkGatewaySKUNameErGw1AZ), armnetwork.VirtualNetworkGatewaySKUNameErGw1AZ, true},
+		{"ExpressRoute scalable SKU", gatewayWithSKU("ErGwScale"), "ErGwScale", true},
+		{"ExpressRoute SKU", gatewayWithSKU(armnetwork.VirtualNetworkGatewaySKUNameStandard), armnetwork.VirtualNetworkGatewaySKUNameStandard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetGatewaySKU(tt.gateway); got != tt.wantSKU {
+				t.Errorf("GetGatewaySKU() = %v, want %v", got, tt.wantSKU)
+			}
+			if got := IsZoneRedundantGateway(tt.gateway); got != tt.want {
+				t.Errorf("IsZoneRedundantGateway() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func gatewayWithSKU(name armnetwork.VirtualNetworkGatewaySKUName) *armnetwork.VirtualNetworkGateway {
+	return &armnetwork.VirtualNetworkGateway{
+		Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+			SKU: &armnetwork.VirtualNetworkGatewaySKU{Name: &name},
+		},
+	}
+}
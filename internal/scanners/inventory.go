@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ResourceInventory - Number of resources of each (lower case) resource type
+type ResourceInventory map[string]int
+
+// ResourceInventoryScanner - Scanner for the resource types of a subscription
+type ResourceInventoryScanner struct {
+	config *ScannerConfig
+}
+
+// Init - Initializes the ResourceInventoryScanner
+func (s *ResourceInventoryScanner) Init(config *ScannerConfig) error {
+	s.config = config
+	return nil
+}
+
+// ListResourceTypes - Counts the resources of each type in the subscription, or in one
+// of its resource groups when resourceGroupName is not empty, with a single Resource Graph query
+func (s *ResourceInventoryScanner) ListResourceTypes(resourceGroupName string) (ResourceInventory, error) {
+	log.Info().Msg("Preflight: Scanning Resource Types")
+	if s.config.Snapshot != nil {
+		return s.config.Snapshot.ResourceTypes(s.config.SubscriptionID, resourceGroupName), nil
+	}
+
+	query := "resources | summarize count = count() by type"
+	if resourceGroupName != "" {
+		query = fmt.Sprintf("resources | where resourceGroup =~ '%s' | summarize count = count() by type", strings.ReplaceAll(resourceGroupName, "'", "\\'"))
+	}
+
+	inventory := ResourceInventory{}
+	graphQuery := GraphQuery{ClientOptions: s.config.ClientOptions}
+	result, err := graphQuery.Query(s.config.Ctx, s.config.Cred, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Data {
+		m, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resourceType, _ := m["type"].(string)
+		count, _ := m["count"].(float64)
+		inventory[strings.ToLower(resourceType)] += int(count)
+	}
+	return inventory, nil
+}
+
+// Contains - Returns true if the inventory has at least one resource of the given types
+func (i ResourceInventory) Contains(resourceTypes []string) bool {
+	for _, t := range resourceTypes {
+		if i[strings.ToLower(t)] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ListResourceGroupsWithType - Returns the resource groups of the subscription that contain resources
+// of the given type, for services whose API can only list the resources of a resource group
+func (s *ResourceInventoryScanner) ListResourceGroupsWithType(resourceType string) ([]string, error) {
+	if s.config.Snapshot != nil {
+		resourceGroups := []string{}
+		for _, rg := range s.config.Snapshot.ResourceGroups(s.config.SubscriptionID) {
+			if s.config.Snapshot.ResourceTypes(s.config.SubscriptionID, rg).Contains([]string{resourceType}) {
+				resourceGroups = append(resourceGroups, rg)
+			}
+		}
+		return resourceGroups, nil
+	}
+
+	query := fmt.Sprintf("resources | where type =~ '%s' | distinct resourceGroup", strings.ReplaceAll(resourceType, "'", "\\'"))
+
+	graphQuery := GraphQuery{ClientOptions: s.config.ClientOptions}
+	result, err := graphQuery.Query(s.config.Ctx, s.config.Cred, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceGroups := []string{}
+	for _, row := range result.Data {
+		m, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if rg, ok := m["resourceGroup"].(string); ok && rg != "" {
+			resourceGroups = append(resourceGroups, rg)
+		}
+	}
+	return resourceGroups, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceInventoryScanner_ListResourceTypes(t *testing.T) {
+	snapshot, err := LoadSnapshot(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		resourceGroup string
+		want          ResourceInventory
+	}{
+		{"subscription", "", ResourceInventory{"microsoft.network/virtualnetworks": 2, "microsoft.sql/servers/databases": 2}},
+		{"resource group", "rg2", ResourceInventory{"microsoft.network/virtualnetworks": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ResourceInventoryScanner{}
+			_ = s.Init(&ScannerConfig{Ctx: context.TODO(), SubscriptionID: "sub1", Snapshot: snapshot})
+			got, err := s.ListResourceTypes(tt.resourceGroup)
+			if err != nil {
+				t.Fatalf("ResourceInventoryScanner.ListResourceTypes() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceInventoryScanner.ListResourceTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceInventory_Contains(t *testing.T) {
+	inventory := ResourceInventory{"microsoft.keyvault/vaults": 1}
+	if !inventory.Contains([]string{"Microsoft.Storage/storageAccounts", "Microsoft.KeyVault/vaults"}) {
+		t.Errorf("ResourceInventory.Contains() = false, want true")
+	}
+	if inventory.Contains([]string{"Microsoft.Storage/storageAccounts"}) {
+		t.Errorf("ResourceInventory.Contains() = true, want false")
+	}
+}
+
+func TestResourceInventoryScanner_ListResourceGroupsWithType(t *testing.T) {
+	snapshot, err := LoadSnapshot(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		resourceType string
+		want         []string
+	}{
+		{"in all resource groups", "Microsoft.Network/virtualNetworks", []string{"RG1", "RG2"}},
+		{"in one resource group", "Microsoft.Sql/servers/databases", []string{"RG1"}},
+		{"not found", "Microsoft.Network/virtualNetworkGateways", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ResourceInventoryScanner{}
+			_ = s.Init(&ScannerConfig{Ctx: context.TODO(), SubscriptionID: "sub1", Snapshot: snapshot})
+			got, err := s.ListResourceGroupsWithType(tt.resourceType)
+			if err != nil {
+				t.Fatalf("ResourceInventoryScanner.ListResourceGroupsWithType() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceInventoryScanner.ListResourceGroupsWithType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
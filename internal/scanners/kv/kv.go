@@ -16,6 +16,17 @@ type KeyVaultScanner struct {
 	vaultsClient *armkeyvault.VaultsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "kv",
+		Description: "Azure Key Vault",
+		ResourceTypes: []string{
+			"Microsoft.KeyVault/vaults",
+		},
+		New: func() scanners.IAzureScanner { return &KeyVaultScanner{} },
+	})
+}
+
 // Init - Initializes the KeyVaultScanner
 func (c *KeyVaultScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *KeyVaultScanner) Scan(resourceGroupName string, scanContext *scanners.S
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*vault.ID),
 			ServiceName:    *vault.Name,
+			ID:             *vault.ID,
 			Type:           *vault.Type,
 			Location:       *vault.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *KeyVaultScanner) Scan(resourceGroupName string, scanContext *scanners.S
 }
 
 func (c *KeyVaultScanner) listVaults(resourceGroupName string) ([]*armkeyvault.Vault, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armkeyvault.Vault](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.KeyVault/vaults")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.vaultsClient.NewListBySubscriptionPager(nil)
+
+		vaults := make([]*armkeyvault.Vault, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			vaults = append(vaults, resp.Value...)
+		}
+		return vaults, nil
+	}
+
 	pager := c.vaultsClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	vaults := make([]*armkeyvault.Vault, 0)
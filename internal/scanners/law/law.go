@@ -0,0 +1,214 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package law
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// The armoperationalinsights module is not a dependency of azqr, so workspaces
+// are read with the REST API through an armresources client, the same way
+// diagnostic settings are.
+const (
+	moduleName        = "armresources"
+	moduleVersion     = "v1.1.1"
+	workspacesVersion = "2022-10-01"
+	usagesVersion     = "2020-08-01"
+	workspaceType     = "Microsoft.OperationalInsights/workspaces"
+	dataIngestedUsage = "DataAnalyzed"
+	bytesPerGB        = 1024 * 1024 * 1024
+)
+
+type (
+	// Workspace - Log Analytics workspace, as returned by ARM
+	Workspace struct {
+		ID         *string              `json:"id,omitempty"`
+		Name       *string              `json:"name,omitempty"`
+		Type       *string              `json:"type,omitempty"`
+		Location   *string              `json:"location,omitempty"`
+		Tags       map[string]*string   `json:"tags,omitempty"`
+		Properties *WorkspaceProperties `json:"properties,omitempty"`
+	}
+
+	// WorkspaceProperties - Log Analytics workspace properties
+	WorkspaceProperties struct {
+		RetentionInDays                 *int32                       `json:"retentionInDays,omitempty"`
+		SKU                             *WorkspaceSKU                `json:"sku,omitempty"`
+		WorkspaceCapping                *WorkspaceCapping            `json:"workspaceCapping,omitempty"`
+		PublicNetworkAccessForIngestion *string                      `json:"publicNetworkAccessForIngestion,omitempty"`
+		PublicNetworkAccessForQuery     *string                      `json:"publicNetworkAccessForQuery,omitempty"`
+		PrivateLinkScopedResources      []*PrivateLinkScopedResource `json:"privateLinkScopedResources,omitempty"`
+	}
+
+	// WorkspaceSKU - Pricing tier of a Log Analytics workspace
+	WorkspaceSKU struct {
+		Name                     *string `json:"name,omitempty"`
+		CapacityReservationLevel *int32  `json:"capacityReservationLevel,omitempty"`
+	}
+
+	// WorkspaceCapping - Daily cap of a Log Analytics workspace. -1 means no cap.
+	WorkspaceCapping struct {
+		DailyQuotaGb *float64 `json:"dailyQuotaGb,omitempty"`
+	}
+
+	// PrivateLinkScopedResource - Azure Monitor Private Link Scope of a Log Analytics workspace
+	PrivateLinkScopedResource struct {
+		ResourceID *string `json:"resourceId,omitempty"`
+		ScopeID    *string `json:"scopeId,omitempty"`
+	}
+
+	workspaceListResult struct {
+		Value    []*Workspace `json:"value"`
+		NextLink *string      `json:"nextLink,omitempty"`
+	}
+
+	usagesListResult struct {
+		Value []struct {
+			Name struct {
+				Value string `json:"value"`
+			} `json:"name"`
+			CurrentValue float64 `json:"currentValue"`
+		} `json:"value"`
+	}
+)
+
+// LogAnalyticsScanner - Scanner for Log Analytics workspaces
+type LogAnalyticsScanner struct {
+	config *scanners.ScannerConfig
+	client *arm.Client
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "law",
+		Description: "Azure Log Analytics workspaces",
+		ResourceTypes: []string{
+			workspaceType,
+		},
+		New: func() scanners.IAzureScanner { return &LogAnalyticsScanner{} },
+	})
+}
+
+// Init - Initializes the LogAnalyticsScanner
+func (c *LogAnalyticsScanner) Init(config *scanners.ScannerConfig) error {
+	c.config = config
+	var err error
+	c.client, err = arm.NewClient(moduleName+".Workspaces", moduleVersion, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Log Analytics workspaces in a Resource Group
+func (c *LogAnalyticsScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Log Analytics workspaces in Resource Group %s", resourceGroupName)
+
+	workspaces, err := c.list(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaces) == 0 {
+		return []scanners.AzureServiceResult{}, nil
+	}
+
+	ingestion := map[string]float64{}
+	for _, w := range workspaces {
+		gb, ok, err := c.dailyIngestion(*w.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ingestion[strings.ToLower(*w.ID)] = gb
+		}
+	}
+
+	engine := scanners.RuleEngine{}
+	rules := c.getRules(ingestion)
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range workspaces {
+		rr := engine.EvaluateRules(rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
+			ServiceName:    *w.Name,
+			ID:             *w.ID,
+			Type:           *w.Type,
+			Location:       *w.Location,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (c *LogAnalyticsScanner) list(resourceGroupName string) ([]*Workspace, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[Workspace](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, workspaceType)
+	}
+
+	path := "/subscriptions/" + c.config.SubscriptionID
+	if resourceGroupName != "" {
+		path += "/resourceGroups/" + resourceGroupName
+	}
+	url := runtime.JoinPaths(c.client.Endpoint(), path, "/providers/Microsoft.OperationalInsights/workspaces") + "?api-version=" + workspacesVersion
+
+	workspaces := make([]*Workspace, 0)
+	for url != "" {
+		page := workspaceListResult{}
+		if err := c.get(c.config.Ctx, url, &page); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, page.Value...)
+
+		url = ""
+		if page.NextLink != nil {
+			url = *page.NextLink
+		}
+	}
+	return workspaces, nil
+}
+
+// dailyIngestion - Returns the GB ingested today by the workspace. The usage is not
+// available when scanning a snapshot.
+func (c *LogAnalyticsScanner) dailyIngestion(workspaceID string) (float64, bool, error) {
+	if c.config.Snapshot != nil {
+		return 0, false, nil
+	}
+
+	url := runtime.JoinPaths(c.client.Endpoint(), workspaceID, "usages") + "?api-version=" + usagesVersion
+	usages := usagesListResult{}
+	if err := c.get(c.config.Ctx, url, &usages); err != nil {
+		return 0, false, err
+	}
+	for _, u := range usages.Value {
+		if strings.EqualFold(u.Name.Value, dataIngestedUsage) {
+			return u.CurrentValue / bytesPerGB, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (c *LogAnalyticsScanner) get(ctx context.Context, url string, result interface{}) error {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, url)
+	if err != nil {
+		return err
+	}
+	req.Raw().Header["Accept"] = []string{"application/json"}
+
+	resp, err := c.client.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	return runtime.UnmarshalAsJSON(resp, result)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package law
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+const (
+	// minRetentionDays - Retention below this is flagged. Stricter policies can be enforced with custom rules.
+	minRetentionDays = 90
+
+	// maxLinkedWorkspaces - Resources of a subscription spread over more workspaces are flagged
+	maxLinkedWorkspaces = 3
+)
+
+// commitmentTiers - Log Analytics commitment tiers in GB per day
+var commitmentTiers = []float64{100, 200, 300, 400, 500, 1000, 2000, 5000, 10000, 25000, 50000}
+
+// GetRules - Returns the rules for the LogAnalyticsScanner
+func (a *LogAnalyticsScanner) GetRules() map[string]scanners.AzureRule {
+	return a.getRules(map[string]float64{})
+}
+
+// getRules - Returns the rules for Log Analytics workspaces. ingestion holds the GB ingested
+// today by each workspace, by lowercase ID.
+func (a *LogAnalyticsScanner) getRules(ingestion map[string]float64) map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"law-001": {
+			Id:          "law-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "Log Analytics workspace should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*Workspace)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/monitor-workspace",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"law-002": {
+			Id:          "law-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+			Description: "Log Analytics workspace should have a SLA",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				return false, "99.9%"
+			},
+			Url:   "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
+			Field: scanners.OverviewFieldSLA,
+		},
+		"law-003": {
+			Id:          "law-003",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityPrivateEndpoint,
+			Description: "Log Analytics workspace should be connected to an Azure Monitor Private Link Scope",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				scoped := w.Properties != nil && len(w.Properties.PrivateLinkScopedResources) > 0
+				return !scoped, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/private-link-security",
+			Field: scanners.OverviewFieldPrivate,
+		},
+		"law-004": {
+			Id:          "law-004",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworking,
+			Description: "Log Analytics workspace should disable public network access for ingestion",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				if w.Properties == nil {
+					return true, ""
+				}
+				return isPublicNetworkAccessEnabled(w.Properties.PublicNetworkAccessForIngestion), ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/private-link-configure#configure-access-to-your-resources",
+		},
+		"law-005": {
+			Id:          "law-005",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworking,
+			Description: "Log Analytics workspace should disable public network access for queries",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				if w.Properties == nil {
+					return true, ""
+				}
+				return isPublicNetworkAccessEnabled(w.Properties.PublicNetworkAccessForQuery), ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/private-link-configure#configure-access-to-your-resources",
+		},
+		"law-006": {
+			Id:          "law-006",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceRetentionPolicies,
+			Description: fmt.Sprintf("Log Analytics workspace should retain data for at least %d days", minRetentionDays),
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				if w.Properties == nil || w.Properties.RetentionInDays == nil {
+					return true, ""
+				}
+				days := *w.Properties.RetentionInDays
+				return days < minRetentionDays, fmt.Sprintf("%d days", days)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/data-retention-archive",
+		},
+		"law-007": {
+			Id:          "law-007",
+			Category:    scanners.RulesCategoryCostOptimization,
+			Subcategory: scanners.RulesSubcategoryCostOptimizationStorage,
+			Description: "Log Analytics workspace should have a daily cap",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				if w.Properties == nil || w.Properties.WorkspaceCapping == nil || w.Properties.WorkspaceCapping.DailyQuotaGb == nil {
+					return true, ""
+				}
+				quota := *w.Properties.WorkspaceCapping.DailyQuotaGb
+				if quota < 0 {
+					return true, ""
+				}
+				return false, fmt.Sprintf("%g GB", quota)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/daily-cap",
+		},
+		"law-008": {
+			Id:          "law-008",
+			Category:    scanners.RulesCategoryCostOptimization,
+			Subcategory: scanners.RulesSubcategoryCostOptimizationStorage,
+			Description: "Log Analytics workspace pricing tier should match its ingestion (point-in-time estimate from today's usage)",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				gb, ok := ingestion[strings.ToLower(*w.ID)]
+				if !ok || w.Properties == nil || w.Properties.SKU == nil || w.Properties.SKU.Name == nil {
+					return false, ""
+				}
+
+				// The usage only covers the current day, so this is a point-in-time estimate: it can
+				// show that a workspace ingests enough for a higher tier, but not that it ingests too little.
+				switch strings.ToLower(*w.Properties.SKU.Name) {
+				case "pergb2018":
+					return gb >= commitmentTiers[0], fmt.Sprintf("Pay-as-you-go, %.1f GB ingested today", gb)
+				case "capacityreservation":
+					level := float64(0)
+					if w.Properties.SKU.CapacityReservationLevel != nil {
+						level = float64(*w.Properties.SKU.CapacityReservationLevel)
+					}
+					next, ok := nextCommitmentTier(level)
+					return ok && gb >= next, fmt.Sprintf("%g GB/day commitment tier, %.1f GB ingested today", level, gb)
+				}
+				return false, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/cost-logs#commitment-tiers",
+		},
+		"law-009": {
+			Id:          "law-009",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceMonitoring,
+			Description: "Resources of a subscription should send their data to a small set of shared Log Analytics workspaces",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				linked := scanContext.WorkspaceResources[strings.ToLower(*w.ID)]
+				// A workspace used by a single resource, while the resources of the subscription are
+				// already spread over many workspaces, should be consolidated. Links are counted per
+				// subscription, as the scan context is built for each subscription.
+				inUse := len(scanContext.WorkspaceResources)
+				broken := inUse > maxLinkedWorkspaces && linked == 1
+				return broken, fmt.Sprintf("%d linked resources, %d workspaces in use in the subscription", linked, inUse)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/workspace-design",
+		},
+		"law-010": {
+			Id:          "law-010",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "Log Analytics workspace Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				caf := strings.HasPrefix(*w.Name, "log")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"law-011": {
+			Id:          "law-011",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Log Analytics workspace should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				w := target.(*Workspace)
+				return len(w.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
+
+// isPublicNetworkAccessEnabled - Returns true unless public network access is Disabled. Enabled is the default.
+func isPublicNetworkAccessEnabled(publicNetworkAccess *string) bool {
+	return publicNetworkAccess == nil || !strings.EqualFold(*publicNetworkAccess, "Disabled")
+}
+
+// nextCommitmentTier - Returns the commitment tier above level
+func nextCommitmentTier(level float64) (float64, bool) {
+	for _, t := range commitmentTiers {
+		if t > level {
+			return t, true
+		}
+	}
+	return 0, false
+}
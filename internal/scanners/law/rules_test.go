@@ -0,0 +1,330 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package law
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestLogAnalyticsScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+		ingestion   map[string]float64
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "LogAnalyticsScanner DiagnosticSettings",
+			fields: fields{
+				rule: "law-001",
+				target: &Workspace{
+					ID: ref.Of("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner SLA",
+			fields: fields{
+				rule:        "law-002",
+				target:      &Workspace{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.9%",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner Private Link Scope",
+			fields: fields{
+				rule: "law-003",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{
+						PrivateLinkScopedResources: []*PrivateLinkScopedResource{
+							{
+								ScopeID: ref.Of("scope"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner public ingestion by default",
+			fields: fields{
+				rule: "law-004",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner public query disabled",
+			fields: fields{
+				rule: "law-005",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{
+						PublicNetworkAccessForQuery: ref.Of("Disabled"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner short retention",
+			fields: fields{
+				rule: "law-006",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{
+						RetentionInDays: ref.Of(int32(30)),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "30 days",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner without daily cap",
+			fields: fields{
+				rule: "law-007",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{
+						WorkspaceCapping: &WorkspaceCapping{
+							DailyQuotaGb: ref.Of(float64(-1)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner with daily cap",
+			fields: fields{
+				rule: "law-007",
+				target: &Workspace{
+					Properties: &WorkspaceProperties{
+						WorkspaceCapping: &WorkspaceCapping{
+							DailyQuotaGb: ref.Of(float64(10)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "10 GB",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner pay-as-you-go above the first commitment tier",
+			fields: fields{
+				rule: "law-008",
+				target: &Workspace{
+					ID: ref.Of("LAW1"),
+					Properties: &WorkspaceProperties{
+						SKU: &WorkspaceSKU{
+							Name: ref.Of("PerGB2018"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				ingestion: map[string]float64{
+					"law1": 150,
+				},
+			},
+			want: want{
+				broken: true,
+				result: "Pay-as-you-go, 150.0 GB ingested today",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner commitment tier within its level",
+			fields: fields{
+				rule: "law-008",
+				target: &Workspace{
+					ID: ref.Of("law1"),
+					Properties: &WorkspaceProperties{
+						SKU: &WorkspaceSKU{
+							Name:                     ref.Of("CapacityReservation"),
+							CapacityReservationLevel: ref.Of(int32(100)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				ingestion: map[string]float64{
+					"law1": 120,
+				},
+			},
+			want: want{
+				broken: false,
+				result: "100 GB/day commitment tier, 120.0 GB ingested today",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner commitment tier at the next level",
+			fields: fields{
+				rule: "law-008",
+				target: &Workspace{
+					ID: ref.Of("law1"),
+					Properties: &WorkspaceProperties{
+						SKU: &WorkspaceSKU{
+							Name:                     ref.Of("CapacityReservation"),
+							CapacityReservationLevel: ref.Of(int32(100)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				ingestion: map[string]float64{
+					"law1": 200,
+				},
+			},
+			want: want{
+				broken: true,
+				result: "100 GB/day commitment tier, 200.0 GB ingested today",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner pricing tier without usage",
+			fields: fields{
+				rule: "law-008",
+				target: &Workspace{
+					ID: ref.Of("law1"),
+					Properties: &WorkspaceProperties{
+						SKU: &WorkspaceSKU{
+							Name: ref.Of("PerGB2018"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner workspace sprawl",
+			fields: fields{
+				rule: "law-009",
+				target: &Workspace{
+					ID: ref.Of("law1"),
+				},
+				scanContext: &scanners.ScanContext{
+					WorkspaceResources: map[string]int{
+						"law1": 1,
+						"law2": 5,
+						"law3": 2,
+						"law4": 1,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1 linked resources, 4 workspaces in use in the subscription",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner shared workspace",
+			fields: fields{
+				rule: "law-009",
+				target: &Workspace{
+					ID: ref.Of("law1"),
+				},
+				scanContext: &scanners.ScanContext{
+					WorkspaceResources: map[string]int{
+						"law1": 1,
+						"law2": 5,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "1 linked resources, 2 workspaces in use in the subscription",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner CAF",
+			fields: fields{
+				rule: "law-010",
+				target: &Workspace{
+					Name: ref.Of("log-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogAnalyticsScanner without tags",
+			fields: fields{
+				rule:        "law-011",
+				target:      &Workspace{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &LogAnalyticsScanner{}
+			rules := s.getRules(tt.fields.ingestion)
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LogAnalyticsScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
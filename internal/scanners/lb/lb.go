@@ -16,6 +16,17 @@ type LoadBalancerScanner struct {
 	client *armnetwork.LoadBalancersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "lb",
+		Description: "Azure Load Balancer",
+		ResourceTypes: []string{
+			"Microsoft.Network/loadBalancers",
+		},
+		New: func() scanners.IAzureScanner { return &LoadBalancerScanner{} },
+	})
+}
+
 // Init - Initializes the LoadBalancerScanner
 func (c *LoadBalancerScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *LoadBalancerScanner) Scan(resourceGroupName string, scanContext *scanne
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *LoadBalancerScanner) Scan(resourceGroupName string, scanContext *scanne
 }
 
 func (c *LoadBalancerScanner) list(resourceGroupName string) ([]*armnetwork.LoadBalancer, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.LoadBalancer](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Network/loadBalancers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListAllPager(nil)
+
+		lbs := make([]*armnetwork.LoadBalancer, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			lbs = append(lbs, resp.Value...)
+		}
+		return lbs, nil
+	}
+
 	pager := c.client.NewListPager(resourceGroupName, nil)
 
 	lbs := make([]*armnetwork.LoadBalancer, 0)
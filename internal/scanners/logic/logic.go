@@ -16,6 +16,17 @@ type LogicAppScanner struct {
 	client *armlogic.WorkflowsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "logic",
+		Description: "Azure Logic Apps",
+		ResourceTypes: []string{
+			"Microsoft.Logic/workflows",
+		},
+		New: func() scanners.IAzureScanner { return &LogicAppScanner{} },
+	})
+}
+
 // Init - Initializes the LogicAppScanner
 func (c *LogicAppScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *LogicAppScanner) Scan(resourceGroupName string, scanContext *scanners.S
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *LogicAppScanner) Scan(resourceGroupName string, scanContext *scanners.S
 }
 
 func (c *LogicAppScanner) list(resourceGroupName string) ([]*armlogic.Workflow, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armlogic.Workflow](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Logic/workflows")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListBySubscriptionPager(nil)
+
+		logicApps := make([]*armlogic.Workflow, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			logicApps = append(logicApps, resp.Value...)
+		}
+		return logicApps, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	logicApps := make([]*armlogic.Workflow, 0)
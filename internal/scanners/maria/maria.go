@@ -17,6 +17,18 @@ type MariaScanner struct {
 	databasesClient *armmariadb.DatabasesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "maria",
+		Description: "Azure Database for MariaDB",
+		ResourceTypes: []string{
+			"Microsoft.DBforMariaDB/servers",
+			"Microsoft.DBforMariaDB/servers/databases",
+		},
+		New: func() scanners.IAzureScanner { return &MariaScanner{} },
+	})
+}
+
 // Init - Initializes the MariaScanner
 func (c *MariaScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -50,14 +62,15 @@ func (c *MariaScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*server.ID),
 			ServiceName:    *server.Name,
+			ID:             *server.ID,
 			Type:           *server.Type,
 			Location:       *server.Location,
 			Rules:          rr,
 		})
 
-		databases, err := c.listDatabases(resourceGroupName, *server.Name)
+		databases, err := c.listDatabases(scanners.GetResourceGroupFromResourceID(*server.ID), *server.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -66,8 +79,9 @@ func (c *MariaScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 
 			results = append(results, scanners.AzureServiceResult{
 				SubscriptionID: c.config.SubscriptionID,
-				ResourceGroup:  resourceGroupName,
+				ResourceGroup:  scanners.GetResourceGroupFromResourceID(*database.ID),
 				ServiceName:    *database.Name,
+				ID:             *database.ID,
 				Type:           *database.Type,
 				Rules:          rr,
 			})
@@ -78,6 +92,24 @@ func (c *MariaScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 }
 
 func (c *MariaScanner) listServers(resourceGroupName string) ([]*armmariadb.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armmariadb.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DBforMariaDB/servers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.serverClient.NewListPager(nil)
+
+		servers := make([]*armmariadb.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.serverClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armmariadb.Server, 0)
@@ -92,6 +124,10 @@ func (c *MariaScanner) listServers(resourceGroupName string) ([]*armmariadb.Serv
 }
 
 func (c *MariaScanner) listDatabases(resourceGroupName, serverName string) ([]*armmariadb.Database, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListChildrenFromSnapshot[armmariadb.Database](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, serverName, "Microsoft.DBforMariaDB/servers/databases")
+	}
+
 	pager := c.databasesClient.NewListByServerPager(resourceGroupName, serverName, nil)
 
 	databases := make([]*armmariadb.Database, 0)
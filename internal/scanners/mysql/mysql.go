@@ -16,6 +16,17 @@ type MySQLScanner struct {
 	postgreClient *armmysql.ServersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "mysql",
+		Description: "Azure Database for MySQL",
+		ResourceTypes: []string{
+			"Microsoft.DBforMySQL/servers",
+		},
+		New: func() scanners.IAzureScanner { return &MySQLScanner{} },
+	})
+}
+
 // Init - Initializes the MySQLScanner
 func (c *MySQLScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *MySQLScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*postgre.ID),
 			ServiceName:    *postgre.Name,
+			ID:             *postgre.ID,
 			Type:           *postgre.Type,
 			Location:       *postgre.Location,
 			Rules:          rr,
@@ -53,6 +65,24 @@ func (c *MySQLScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 }
 
 func (c *MySQLScanner) listMySQL(resourceGroupName string) ([]*armmysql.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armmysql.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DBforMySQL/servers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.postgreClient.NewListPager(nil)
+
+		servers := make([]*armmysql.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.postgreClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armmysql.Server, 0)
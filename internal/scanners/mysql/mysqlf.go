@@ -16,6 +16,17 @@ type MySQLFlexibleScanner struct {
 	flexibleClient *armmysqlflexibleservers.ServersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "mysql",
+		Description: "Azure Database for MySQL",
+		ResourceTypes: []string{
+			"Microsoft.DBforMySQL/flexibleServers",
+		},
+		New: func() scanners.IAzureScanner { return &MySQLFlexibleScanner{} },
+	})
+}
+
 // Init - Initializes the MySQLFlexibleScanner
 func (c *MySQLFlexibleScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *MySQLFlexibleScanner) Scan(resourceGroupName string, scanContext *scann
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*postgre.ID),
 			ServiceName:    *postgre.Name,
+			ID:             *postgre.ID,
 			Type:           *postgre.Type,
 			Location:       *postgre.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *MySQLFlexibleScanner) Scan(resourceGroupName string, scanContext *scann
 	return results, nil
 }
 func (c *MySQLFlexibleScanner) listFlexiblePostgre(resourceGroupName string) ([]*armmysqlflexibleservers.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armmysqlflexibleservers.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DBforMySQL/flexibleServers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.flexibleClient.NewListPager(nil)
+
+		servers := make([]*armmysqlflexibleservers.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.flexibleClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armmysqlflexibleservers.Server, 0)
@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package nsg
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// NSGScanner - Scanner for Network Security Groups
+type NSGScanner struct {
+	config         *scanners.ScannerConfig
+	client         *armnetwork.SecurityGroupsClient
+	flowLogsClient *armnetwork.FlowLogsClient
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "nsg",
+		Description: "Azure Network Security Groups",
+		ResourceTypes: []string{
+			"Microsoft.Network/networkSecurityGroups",
+		},
+		New: func() scanners.IAzureScanner { return &NSGScanner{} },
+	})
+}
+
+// Init - Initializes the NSGScanner
+func (c *NSGScanner) Init(config *scanners.ScannerConfig) error {
+	c.config = config
+	var err error
+	c.client, err = armnetwork.NewSecurityGroupsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.flowLogsClient, err = armnetwork.NewFlowLogsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Network Security Groups in a Resource Group
+func (c *NSGScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Network Security Groups in Resource Group %s", resourceGroupName)
+
+	nsgs, err := c.list(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	flowLogs, err := c.listFlowLogs(nsgs)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.getRules(flowLogs)
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range nsgs {
+		rr := engine.EvaluateRules(rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
+			ServiceName:    *w.Name,
+			ID:             *w.ID,
+			Type:           *w.Type,
+			Location:       *w.Location,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (c *NSGScanner) list(resourceGroupName string) ([]*armnetwork.SecurityGroup, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.SecurityGroup](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Network/networkSecurityGroups")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListAllPager(nil)
+
+		nsgs := make([]*armnetwork.SecurityGroup, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			nsgs = append(nsgs, resp.Value...)
+		}
+		return nsgs, nil
+	}
+
+	pager := c.client.NewListPager(resourceGroupName, nil)
+
+	nsgs := make([]*armnetwork.SecurityGroup, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		nsgs = append(nsgs, resp.Value...)
+	}
+	return nsgs, nil
+}
+
+// listFlowLogs - Returns whether the flow logs referenced by the Network Security Groups are
+// enabled, by lowercase flow log ID. ARM only returns the IDs of the flow logs of a Network
+// Security Group, so they are read from their Network Watchers.
+func (c *NSGScanner) listFlowLogs(nsgs []*armnetwork.SecurityGroup) (map[string]bool, error) {
+	res := map[string]bool{}
+
+	watchers := map[string]*arm.ResourceID{}
+	for _, g := range nsgs {
+		if g.Properties == nil {
+			continue
+		}
+		for _, f := range g.Properties.FlowLogs {
+			if f.ID == nil {
+				continue
+			}
+			id, err := arm.ParseResourceID(*f.ID)
+			if err != nil || id.Parent == nil || !strings.EqualFold(id.SubscriptionID, c.config.SubscriptionID) {
+				continue
+			}
+			watchers[strings.ToLower(id.Parent.String())] = id.Parent
+		}
+	}
+
+	for _, w := range watchers {
+		flowLogs, err := c.listWatcherFlowLogs(w.ResourceGroupName, w.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range flowLogs {
+			res[strings.ToLower(*f.ID)] = f.Properties != nil && f.Properties.Enabled != nil && *f.Properties.Enabled
+		}
+	}
+	return res, nil
+}
+
+func (c *NSGScanner) listWatcherFlowLogs(resourceGroupName, networkWatcherName string) ([]*armnetwork.FlowLog, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListChildrenFromSnapshot[armnetwork.FlowLog](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, networkWatcherName, "Microsoft.Network/networkWatchers/flowLogs")
+	}
+
+	pager := c.flowLogsClient.NewListPager(resourceGroupName, networkWatcherName, nil)
+
+	flowLogs := make([]*armnetwork.FlowLog, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		flowLogs = append(flowLogs, resp.Value...)
+	}
+	return flowLogs, nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package nsg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// managementPorts - Remote management ports: SSH, RDP and WinRM
+var managementPorts = []int{22, 3389, 5985, 5986}
+
+// maxPortRangeSize - Inbound rules that allow more ports than this are considered overly broad
+const maxPortRangeSize = 1024
+
+// GetRules - Returns the rules for the NSGScanner
+func (a *NSGScanner) GetRules() map[string]scanners.AzureRule {
+	return a.getRules(map[string]bool{})
+}
+
+// getRules - Returns the rules for the NSGScanner. flowLogs tells, by lowercase flow log ID,
+// whether a flow log referenced by a Network Security Group is enabled.
+func (a *NSGScanner) getRules(flowLogs map[string]bool) map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"nsg-001": {
+			Id:          "nsg-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "Network Security Group should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*armnetwork.SecurityGroup)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/virtual-network/virtual-network-nsg-manage-log",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"nsg-002": {
+			Id:          "nsg-002",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworkSecurityGroups,
+			Description: "Network Security Group should not allow management ports (22, 3389, 5985, 5986) from the Internet",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				names := []string{}
+				for _, r := range inboundAllowRules(g) {
+					if r.Properties.Protocol != nil && *r.Properties.Protocol == armnetwork.SecurityRuleProtocolIcmp {
+						continue
+					}
+					if !allowsInternet(r.Properties) {
+						continue
+					}
+					for _, p := range managementPorts {
+						if allowsPort(r.Properties, p) {
+							names = append(names, *r.Name)
+							break
+						}
+					}
+				}
+				return len(names) > 0, strings.Join(names, ", ")
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/security/fundamentals/network-best-practices#disable-rdpssh-access-to-virtual-machines",
+		},
+		"nsg-003": {
+			Id:          "nsg-003",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworkSecurityGroups,
+			Description: "Network Security Group should not have inbound rules allowing all ports or broad port ranges",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				names := []string{}
+				for _, r := range inboundAllowRules(g) {
+					for _, pr := range destinationPortRanges(r.Properties) {
+						from, to, ok := parsePortRange(pr)
+						if ok && to-from+1 > maxPortRangeSize {
+							names = append(names, *r.Name)
+							break
+						}
+					}
+				}
+				return len(names) > 0, strings.Join(names, ", ")
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview#security-rules",
+		},
+		"nsg-004": {
+			Id:          "nsg-004",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworkSecurityGroups,
+			Description: "Network Security Group should have flow logs enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				if g.Properties == nil {
+					return true, ""
+				}
+				for _, f := range g.Properties.FlowLogs {
+					if f.ID != nil && flowLogs[strings.ToLower(*f.ID)] {
+						return false, ""
+					}
+				}
+				return true, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/network-watcher/nsg-flow-logs-overview",
+		},
+		"nsg-005": {
+			Id:          "nsg-005",
+			Category:    scanners.RulesCategorySecurity,
+			Subcategory: scanners.RulesSubcategorySecurityNetworkSecurityGroups,
+			Description: "Network Security Group should be associated with a subnet or a network interface",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				associated := g.Properties != nil && (len(g.Properties.Subnets) > 0 || len(g.Properties.NetworkInterfaces) > 0)
+				return !associated, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-network/network-security-group-how-it-works",
+		},
+		"nsg-006": {
+			Id:          "nsg-006",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "Network Security Group Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				caf := strings.HasPrefix(*g.Name, "nsg")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"nsg-007": {
+			Id:          "nsg-007",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Network Security Group should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.SecurityGroup)
+				return len(g.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
+
+// inboundAllowRules - Returns the user defined inbound rules that allow traffic
+func inboundAllowRules(g *armnetwork.SecurityGroup) []*armnetwork.SecurityRule {
+	rules := []*armnetwork.SecurityRule{}
+	if g.Properties == nil {
+		return rules
+	}
+	for _, r := range g.Properties.SecurityRules {
+		if r.Properties == nil || r.Properties.Direction == nil || r.Properties.Access == nil {
+			continue
+		}
+		if *r.Properties.Direction == armnetwork.SecurityRuleDirectionInbound && *r.Properties.Access == armnetwork.SecurityRuleAccessAllow {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// allowsInternet - Returns true if the rule source is any address or the Internet service tag
+func allowsInternet(p *armnetwork.SecurityRulePropertiesFormat) bool {
+	prefixes := []*string{p.SourceAddressPrefix}
+	prefixes = append(prefixes, p.SourceAddressPrefixes...)
+	for _, prefix := range prefixes {
+		if prefix == nil {
+			continue
+		}
+		switch strings.ToLower(*prefix) {
+		case "*", "internet", "any", "0.0.0.0/0", "::/0":
+			return true
+		}
+	}
+	return false
+}
+
+// allowsPort - Returns true if any of the rule destination port ranges includes port
+func allowsPort(p *armnetwork.SecurityRulePropertiesFormat, port int) bool {
+	for _, pr := range destinationPortRanges(p) {
+		from, to, ok := parsePortRange(pr)
+		if ok && from <= port && port <= to {
+			return true
+		}
+	}
+	return false
+}
+
+func destinationPortRanges(p *armnetwork.SecurityRulePropertiesFormat) []string {
+	ranges := []string{}
+	if p.DestinationPortRange != nil {
+		ranges = append(ranges, *p.DestinationPortRange)
+	}
+	for _, pr := range p.DestinationPortRanges {
+		if pr != nil {
+			ranges = append(ranges, *pr)
+		}
+	}
+	return ranges
+}
+
+// parsePortRange - Parses a port range such as *, 22 or 1000-2000
+func parsePortRange(portRange string) (int, int, bool) {
+	portRange = strings.TrimSpace(portRange)
+	if portRange == "*" {
+		return 0, 65535, true
+	}
+
+	from, to, found := strings.Cut(portRange, "-")
+	start, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, false
+	}
+	if !found {
+		return start, start, true
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(to))
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
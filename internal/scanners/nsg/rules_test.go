@@ -0,0 +1,303 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package nsg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+func inboundRule(name, source, ports string) *armnetwork.SecurityRule {
+	return &armnetwork.SecurityRule{
+		Name: ref.Of(name),
+		Properties: &armnetwork.SecurityRulePropertiesFormat{
+			Access:               ref.Of(armnetwork.SecurityRuleAccessAllow),
+			Direction:            ref.Of(armnetwork.SecurityRuleDirectionInbound),
+			Protocol:             ref.Of(armnetwork.SecurityRuleProtocolTCP),
+			SourceAddressPrefix:  ref.Of(source),
+			DestinationPortRange: ref.Of(ports),
+		},
+	}
+}
+
+func flowLogID(name string) string {
+	return "/subscriptions/sub/resourceGroups/NetworkWatcherRG/providers/Microsoft.Network/networkWatchers/NetworkWatcher_westeurope/flowLogs/" + name
+}
+
+func TestNSGScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+		flowLogs    map[string]bool
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "NSGScanner DiagnosticSettings",
+			fields: fields{
+				rule: "nsg-001",
+				target: &armnetwork.SecurityGroup{
+					ID: ref.Of("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner SSH and RDP from the Internet",
+			fields: fields{
+				rule: "nsg-002",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							inboundRule("AllowSSH", "*", "22"),
+							inboundRule("AllowRDP", "Internet", "3380-3390"),
+							inboundRule("AllowHTTPS", "*", "443"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "AllowSSH, AllowRDP",
+			},
+		},
+		{
+			name: "NSGScanner WinRM from a private range",
+			fields: fields{
+				rule: "nsg-002",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							inboundRule("AllowWinRM", "10.0.0.0/8", "5985"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner SSH denied from the Internet",
+			fields: fields{
+				rule: "nsg-002",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							{
+								Name: ref.Of("DenySSH"),
+								Properties: &armnetwork.SecurityRulePropertiesFormat{
+									Access:               ref.Of(armnetwork.SecurityRuleAccessDeny),
+									Direction:            ref.Of(armnetwork.SecurityRuleDirectionInbound),
+									SourceAddressPrefix:  ref.Of("*"),
+									DestinationPortRange: ref.Of("22"),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner broad port ranges",
+			fields: fields{
+				rule: "nsg-003",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							inboundRule("AllowAll", "10.0.0.0/8", "*"),
+							inboundRule("AllowRange", "10.0.0.0/8", "1000-5000"),
+							inboundRule("AllowGatewayManager", "GatewayManager", "65200-65535"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "AllowAll, AllowRange",
+			},
+		},
+		{
+			name: "NSGScanner flow logs enabled",
+			fields: fields{
+				rule: "nsg-004",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						FlowLogs: []*armnetwork.FlowLog{
+							{ID: ref.Of(flowLogID("fl-disabled"))},
+							{ID: ref.Of(flowLogID("FL-ENABLED"))},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				flowLogs: map[string]bool{
+					strings.ToLower(flowLogID("fl-disabled")): false,
+					strings.ToLower(flowLogID("fl-enabled")):  true,
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner flow logs disabled",
+			fields: fields{
+				rule: "nsg-004",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						FlowLogs: []*armnetwork.FlowLog{
+							{ID: ref.Of(flowLogID("fl-disabled"))},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				flowLogs: map[string]bool{
+					strings.ToLower(flowLogID("fl-disabled")): false,
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner flow log not found",
+			fields: fields{
+				rule: "nsg-004",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						FlowLogs: []*armnetwork.FlowLog{
+							{ID: ref.Of(flowLogID("fl-deleted"))},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+				flowLogs:    map[string]bool{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner without flow logs",
+			fields: fields{
+				rule: "nsg-004",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner not associated",
+			fields: fields{
+				rule: "nsg-005",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner associated with a subnet",
+			fields: fields{
+				rule: "nsg-005",
+				target: &armnetwork.SecurityGroup{
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						Subnets: []*armnetwork.Subnet{
+							{
+								ID: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/default"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner CAF",
+			fields: fields{
+				rule: "nsg-006",
+				target: &armnetwork.SecurityGroup{
+					Name: ref.Of("nsg-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "NSGScanner without tags",
+			fields: fields{
+				rule:        "nsg-007",
+				target:      &armnetwork.SecurityGroup{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &NSGScanner{}
+			rules := s.getRules(tt.fields.flowLogs)
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NSGScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -30,6 +30,17 @@ func (s *PrivateEndpointScanner) Init(config *ScannerConfig) error {
 func (s *PrivateEndpointScanner) ListResourcesWithPrivateEndpoints() (map[string]bool, error) {
 	log.Info().Msg("Preflight: Scanning Private Endpoints")
 	res := map[string]bool{}
+	if s.config.Snapshot != nil {
+		endpoints, err := ListFromSnapshot[armnetwork.PrivateEndpoint](s.config.Snapshot, s.config.SubscriptionID, "", "Microsoft.Network/privateEndpoints")
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range endpoints {
+			addPrivateLinkServiceIDs(res, v)
+		}
+		return res, nil
+	}
+
 	if s.hasPrivateEndpointFunc == nil {
 		opt := armnetwork.PrivateEndpointsClientListBySubscriptionOptions{}
 
@@ -42,11 +53,7 @@ func (s *PrivateEndpointScanner) ListResourcesWithPrivateEndpoints() (map[string
 			}
 
 			for _, v := range resp.Value {
-				for _, c := range v.Properties.PrivateLinkServiceConnections {
-					if len(*c.Properties.PrivateLinkServiceID) > 0 {
-						res[*c.Properties.PrivateLinkServiceID] = true
-					}
-				}
+				addPrivateLinkServiceIDs(res, v)
 			}
 		}
 
@@ -55,3 +62,14 @@ func (s *PrivateEndpointScanner) ListResourcesWithPrivateEndpoints() (map[string
 
 	return s.hasPrivateEndpointFunc()
 }
+
+func addPrivateLinkServiceIDs(res map[string]bool, v *armnetwork.PrivateEndpoint) {
+	if v.Properties == nil {
+		return
+	}
+	for _, c := range v.Properties.PrivateLinkServiceConnections {
+		if c.Properties != nil && c.Properties.PrivateLinkServiceID != nil && len(*c.Properties.PrivateLinkServiceID) > 0 {
+			res[*c.Properties.PrivateLinkServiceID] = true
+		}
+	}
+}
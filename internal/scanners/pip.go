@@ -29,6 +29,17 @@ func (s *PublicIPScanner) Init(config *ScannerConfig) error {
 func (s *PublicIPScanner) ListPublicIPs() (map[string]*armnetwork.PublicIPAddress, error) {
 	log.Info().Msg("Preflight: Scanning Public IPs")
 	res := map[string]*armnetwork.PublicIPAddress{}
+	if s.config.Snapshot != nil {
+		pips, err := ListFromSnapshot[armnetwork.PublicIPAddress](s.config.Snapshot, s.config.SubscriptionID, "", "Microsoft.Network/publicIPAddresses")
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range pips {
+			res[*v.ID] = v
+		}
+		return res, nil
+	}
+
 	opt := armnetwork.PublicIPAddressesClientListAllOptions{}
 
 	pager := s.client.NewListAllPager(&opt)
@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package pip
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// PublicIPAddressScanner - Scanner for Public IP Addresses
+type PublicIPAddressScanner struct {
+	config *scanners.ScannerConfig
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "pip",
+		Description: "Azure Public IP Addresses",
+		ResourceTypes: []string{
+			"Microsoft.Network/publicIPAddresses",
+		},
+		New: func() scanners.IAzureScanner { return &PublicIPAddressScanner{} },
+	})
+}
+
+// Init - Initializes the PublicIPAddressScanner
+func (c *PublicIPAddressScanner) Init(config *scanners.ScannerConfig) error {
+	c.config = config
+	return nil
+}
+
+// Scan - Scans all Public IP Addresses in a Resource Group. The Public IPs are not listed
+// again: they are read from the ScanContext, where the subscription preflight put them.
+func (c *PublicIPAddressScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Public IP Addresses in Resource Group %s", resourceGroupName)
+
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, pip := range c.list(resourceGroupName, scanContext) {
+		rr := engine.EvaluateRules(rules, pip, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*pip.ID),
+			ServiceName:    *pip.Name,
+			ID:             *pip.ID,
+			Type:           *pip.Type,
+			Location:       *pip.Location,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (c *PublicIPAddressScanner) list(resourceGroupName string, scanContext *scanners.ScanContext) []*armnetwork.PublicIPAddress {
+	pips := make([]*armnetwork.PublicIPAddress, 0)
+	if scanContext == nil {
+		return pips
+	}
+
+	for _, pip := range scanContext.PublicIPs {
+		if resourceGroupName == "" || strings.EqualFold(scanners.GetResourceGroupFromResourceID(*pip.ID), resourceGroupName) {
+			pips = append(pips, pip)
+		}
+	}
+	sort.Slice(pips, func(i, j int) bool {
+		return *pips[i].ID < *pips[j].ID
+	})
+	return pips
+}
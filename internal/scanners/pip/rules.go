@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package pip
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// GetRules - Returns the rules for the PublicIPAddressScanner
+func (a *PublicIPAddressScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"pip-001": {
+			Id:          "pip-001",
+			Category:    scanners.RulesCategoryCostOptimization,
+			Subcategory: scanners.RulesSubcategoryCostOptimizationUnusedResources,
+			Description: "Public IP Address should be associated with a resource",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				p := target.(*armnetwork.PublicIPAddress)
+				// Public IPs of NAT Gateways have no IP configuration
+				unused := p.Properties == nil || (p.Properties.IPConfiguration == nil && p.Properties.NatGateway == nil)
+				return unused, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/advisor/advisor-cost-recommendations#delete-public-ip-addresses-that-arent-associated-with-a-resource",
+		},
+		"pip-002": {
+			Id:          "pip-002",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "Public IP Address Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				p := target.(*armnetwork.PublicIPAddress)
+				caf := strings.HasPrefix(*p.Name, "pip")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"pip-003": {
+			Id:          "pip-003",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Public IP Address should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				p := target.(*armnetwork.PublicIPAddress)
+				return len(p.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package pip
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+func TestPublicIPAddressScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "PublicIPAddressScanner unassociated Public IP",
+			fields: fields{
+				rule: "pip-001",
+				target: &armnetwork.PublicIPAddress{
+					Properties: &armnetwork.PublicIPAddressPropertiesFormat{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "PublicIPAddressScanner Public IP with IP configuration",
+			fields: fields{
+				rule: "pip-001",
+				target: &armnetwork.PublicIPAddress{
+					Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+						IPConfiguration: &armnetwork.IPConfiguration{
+							ID: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/nic1/ipConfigurations/ipconfig1"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "PublicIPAddressScanner Public IP of a NAT Gateway",
+			fields: fields{
+				rule: "pip-001",
+				target: &armnetwork.PublicIPAddress{
+					Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+						NatGateway: &armnetwork.NatGateway{
+							ID: ref.Of("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/natGateways/ng1"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "PublicIPAddressScanner CAF",
+			fields: fields{
+				rule: "pip-002",
+				target: &armnetwork.PublicIPAddress{
+					Name: ref.Of("pip-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "PublicIPAddressScanner without tags",
+			fields: fields{
+				rule:        "pip-003",
+				target:      &armnetwork.PublicIPAddress{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &PublicIPAddressScanner{}
+			rules := s.GetRules()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublicIPAddressScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
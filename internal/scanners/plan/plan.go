@@ -19,6 +19,18 @@ type AppServiceScanner struct {
 	sitesClient *armappservice.WebAppsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "plan",
+		Description: "Azure App Service",
+		ResourceTypes: []string{
+			"Microsoft.Web/serverFarms",
+			"Microsoft.Web/sites",
+		},
+		New: func() scanners.IAzureScanner { return &AppServiceScanner{} },
+	})
+}
+
 // Init - Initializes the AppServiceScanner
 func (a *AppServiceScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -54,14 +66,15 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: a.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*p.ID),
 			ServiceName:    *p.Name,
+			ID:             *p.ID,
 			Type:           *p.Type,
 			Location:       *p.Location,
 			Rules:          rr,
 		})
 
-		sites, err := a.listSites(resourceGroupName, *p.Name)
+		sites, err := a.listSites(scanners.GetResourceGroupFromResourceID(*p.ID), *p.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -76,8 +89,9 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 
 				result = scanners.AzureServiceResult{
 					SubscriptionID: a.config.SubscriptionID,
-					ResourceGroup:  resourceGroupName,
+					ResourceGroup:  scanners.GetResourceGroupFromResourceID(*s.ID),
 					ServiceName:    *s.Name,
+					ID:             *s.ID,
 					Type:           *s.Type,
 					Location:       *p.Location,
 					Rules:          rr,
@@ -87,8 +101,9 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 
 				result = scanners.AzureServiceResult{
 					SubscriptionID: a.config.SubscriptionID,
-					ResourceGroup:  resourceGroupName,
+					ResourceGroup:  scanners.GetResourceGroupFromResourceID(*s.ID),
 					ServiceName:    *s.Name,
+					ID:             *s.ID,
 					Type:           *s.Type,
 					Location:       *p.Location,
 					Rules:          rr,
@@ -97,8 +112,9 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 				rr := engine.EvaluateRules(appRules, s, scanContext)
 				result = scanners.AzureServiceResult{
 					SubscriptionID: a.config.SubscriptionID,
-					ResourceGroup:  resourceGroupName,
+					ResourceGroup:  scanners.GetResourceGroupFromResourceID(*s.ID),
 					ServiceName:    *s.Name,
+					ID:             *s.ID,
 					Type:           *s.Type,
 					Location:       *p.Location,
 					Rules:          rr,
@@ -113,6 +129,24 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 }
 
 func (a *AppServiceScanner) listPlans(resourceGroupName string) ([]*armappservice.Plan, error) {
+	if a.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armappservice.Plan](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Web/serverFarms")
+	}
+
+	if resourceGroupName == "" {
+		pager := a.plansClient.NewListPager(nil)
+		results := []*armappservice.Plan{}
+		for pager.More() {
+			resp, err := pager.NextPage(a.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, resp.Value...)
+		}
+
+		return results, nil
+	}
+
 	pager := a.plansClient.NewListByResourceGroupPager(resourceGroupName, nil)
 	results := []*armappservice.Plan{}
 	for pager.More() {
@@ -127,6 +161,10 @@ func (a *AppServiceScanner) listPlans(resourceGroupName string) ([]*armappservic
 }
 
 func (a *AppServiceScanner) listSites(resourceGroupName string, plan string) ([]*armappservice.Site, error) {
+	if a.config.Snapshot != nil {
+		return a.listSitesFromSnapshot(resourceGroupName, plan)
+	}
+
 	pager := a.plansClient.NewListWebAppsPager(resourceGroupName, plan, nil)
 	results := []*armappservice.Site{}
 	for pager.More() {
@@ -138,3 +176,22 @@ func (a *AppServiceScanner) listSites(resourceGroupName string, plan string) ([]
 	}
 	return results, nil
 }
+
+// listSitesFromSnapshot - Returns the sites of the snapshot hosted in the given plan
+func (a *AppServiceScanner) listSitesFromSnapshot(resourceGroupName string, plan string) ([]*armappservice.Site, error) {
+	sites, err := scanners.ListFromSnapshot[armappservice.Site](a.config.Snapshot, a.config.SubscriptionID, resourceGroupName, "Microsoft.Web/sites")
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*armappservice.Site{}
+	for _, s := range sites {
+		if s.Properties == nil || s.Properties.ServerFarmID == nil {
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(*s.Properties.ServerFarmID), "/serverfarms/"+strings.ToLower(plan)) {
+			results = append(results, s)
+		}
+	}
+	return results, nil
+}
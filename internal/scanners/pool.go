@@ -0,0 +1,43 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"sync"
+)
+
+// WorkerPool - Bounds the number of tasks running at once across all its callers
+type WorkerPool struct {
+	slots chan struct{}
+}
+
+// NewWorkerPool - Creates a WorkerPool running at most size tasks at once
+func NewWorkerPool(size int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &WorkerPool{
+		slots: make(chan struct{}, size),
+	}
+}
+
+// Run - Runs the tasks and waits for them to complete. A nil pool runs all the tasks at once.
+// Tasks must not call Run on the same pool, since they could wait forever for a free slot.
+func (p *WorkerPool) Run(tasks ...func()) {
+	var wg sync.WaitGroup
+	wg.Add(len(tasks))
+	for _, task := range tasks {
+		if p != nil {
+			p.slots <- struct{}{}
+		}
+		go func(task func()) {
+			defer wg.Done()
+			if p != nil {
+				defer func() { <-p.slots }()
+			}
+			task()
+		}(task)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_Run(t *testing.T) {
+	pool := NewWorkerPool(2)
+
+	var running, peak int32
+	tasks := []func(){}
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	pool.Run(tasks...)
+
+	if peak > 2 {
+		t.Errorf("WorkerPool.Run() ran %d tasks at once, want at most 2", peak)
+	}
+	if running != 0 {
+		t.Errorf("WorkerPool.Run() returned with %d tasks running", running)
+	}
+}
@@ -16,6 +16,17 @@ type PostgreScanner struct {
 	postgreClient *armpostgresql.ServersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "psql",
+		Description: "Azure Database for PostgreSQL",
+		ResourceTypes: []string{
+			"Microsoft.DBforPostgreSQL/servers",
+		},
+		New: func() scanners.IAzureScanner { return &PostgreScanner{} },
+	})
+}
+
 // Init - Initializes the PostgreScanner
 func (c *PostgreScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *PostgreScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*postgre.ID),
 			ServiceName:    *postgre.Name,
+			ID:             *postgre.ID,
 			Type:           *postgre.Type,
 			Location:       *postgre.Location,
 			Rules:          rr,
@@ -53,6 +65,24 @@ func (c *PostgreScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 }
 
 func (c *PostgreScanner) listPostgre(resourceGroupName string) ([]*armpostgresql.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armpostgresql.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DBforPostgreSQL/servers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.postgreClient.NewListPager(nil)
+
+		servers := make([]*armpostgresql.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.postgreClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armpostgresql.Server, 0)
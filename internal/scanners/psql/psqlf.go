@@ -16,6 +16,17 @@ type PostgreFlexibleScanner struct {
 	flexibleClient *armpostgresqlflexibleservers.ServersClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "psql",
+		Description: "Azure Database for PostgreSQL",
+		ResourceTypes: []string{
+			"Microsoft.DBforPostgreSQL/flexibleServers",
+		},
+		New: func() scanners.IAzureScanner { return &PostgreFlexibleScanner{} },
+	})
+}
+
 // Init - Initializes the PostgreFlexibleScanner
 func (c *PostgreFlexibleScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *PostgreFlexibleScanner) Scan(resourceGroupName string, scanContext *sca
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*postgre.ID),
 			ServiceName:    *postgre.Name,
+			ID:             *postgre.ID,
 			Type:           *postgre.Type,
 			Location:       *postgre.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *PostgreFlexibleScanner) Scan(resourceGroupName string, scanContext *sca
 	return results, nil
 }
 func (c *PostgreFlexibleScanner) listFlexiblePostgre(resourceGroupName string) ([]*armpostgresqlflexibleservers.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armpostgresqlflexibleservers.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.DBforPostgreSQL/flexibleServers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.flexibleClient.NewListPager(nil)
+
+		servers := make([]*armpostgresqlflexibleservers.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.flexibleClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armpostgresqlflexibleservers.Server, 0)
@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultRateLimit - Default number of requests per second sent to each subscription
+	DefaultRateLimit = 20
+	// DefaultRateLimitBurst - Default number of requests that can be sent at once to each subscription
+	DefaultRateLimitBurst = 100
+
+	rateLimitRemainingHeaderPrefix = "x-ms-ratelimit-remaining-"
+)
+
+type (
+	// RateLimiter - Pipeline policy throttling the requests sent to each subscription with a
+	// token bucket. The bucket is drained when ARM reports few remaining requests in the
+	// x-ms-ratelimit-remaining-* headers, and paused for the Retry-After of throttled responses.
+	// Register it in the PerRetryPolicies of the client options so every attempt is throttled.
+	RateLimiter struct {
+		rate    float64
+		burst   float64
+		mutex   sync.Mutex
+		buckets map[string]*tokenBucket
+	}
+
+	tokenBucket struct {
+		mutex       sync.Mutex
+		tokens      float64
+		last        time.Time
+		pausedUntil time.Time
+	}
+)
+
+// NewRateLimiter - Creates a RateLimiter allowing rate requests per second, and bursts of burst
+// requests, per subscription
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		rate = DefaultRateLimit
+	}
+	if burst <= 0 {
+		burst = DefaultRateLimitBurst
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// Do - Implements policy.Policy
+func (l *RateLimiter) Do(req *policy.Request) (*http.Response, error) {
+	raw := req.Raw()
+	bucket := l.bucket(strings.ToLower(resourceIDSegment(raw.URL.Path, "subscriptions")))
+
+	if err := l.wait(req, bucket); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Next()
+	if err != nil {
+		return resp, err
+	}
+
+	l.observe(bucket, resp, time.Now())
+	return resp, nil
+}
+
+func (l *RateLimiter) bucket(subscriptionID string) *tokenBucket {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	b, ok := l.buckets[subscriptionID]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: time.Now()}
+		l.buckets[subscriptionID] = b
+	}
+	return b
+}
+
+// wait - Blocks until the bucket has a token or the request context is done
+func (l *RateLimiter) wait(req *policy.Request, b *tokenBucket) error {
+	ctx := req.Raw().Context()
+	for {
+		delay := l.take(b, time.Now())
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take - Takes a token and returns 0, or returns how long to wait for the next token
+func (l *RateLimiter) take(b *tokenBucket, now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// observe - Adjusts the bucket to the remaining requests and Retry-After reported by ARM
+func (l *RateLimiter) observe(b *tokenBucket, resp *http.Response, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if remaining, ok := remainingRequests(resp.Header); ok && float64(remaining) < b.tokens {
+		b.tokens = float64(remaining)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		retryAfter := retryAfter(resp.Header, now)
+		if retryAfter <= 0 {
+			retryAfter = time.Duration(float64(time.Second) / l.rate)
+		}
+		if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+			log.Debug().Msgf("Throttled by Azure: pausing requests to %s for %s", resp.Request.URL.Host, retryAfter)
+			b.pausedUntil = until
+		}
+		b.tokens = 0
+	}
+}
+
+// remainingRequests - Returns the lowest count of the x-ms-ratelimit-remaining-* headers. Some
+// headers list several quotas (e.g. "Microsoft.Compute/HighCostGet3Min;107,Microsoft.Compute/HighCostGet30Min;527").
+func remainingRequests(header http.Header) (int, bool) {
+	lowest, found := 0, false
+	for name, values := range header {
+		if !strings.HasPrefix(strings.ToLower(name), rateLimitRemainingHeaderPrefix) {
+			continue
+		}
+		for _, value := range values {
+			for _, quota := range strings.Split(value, ",") {
+				if i := strings.LastIndex(quota, ";"); i >= 0 {
+					quota = quota[i+1:]
+				}
+				n, err := strconv.Atoi(strings.TrimSpace(quota))
+				if err != nil {
+					continue
+				}
+				if !found || n < lowest {
+					lowest, found = n, true
+				}
+			}
+		}
+	}
+	return lowest, found
+}
+
+// retryAfter - Returns the delay requested by the retry-after-ms, x-ms-retry-after-ms or
+// Retry-After (seconds or HTTP date) headers
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	for _, name := range []string{"Retry-After-Ms", "X-Ms-Retry-After-Ms"} {
+		if v := header.Get(name); v != "" {
+			if ms, err := strconv.Atoi(v); err == nil {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_remainingRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+		wantOk bool
+	}{
+		{
+			name:   "no header",
+			header: http.Header{},
+			wantOk: false,
+		},
+		{
+			name: "subscription reads",
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Subscription-Reads": {"11999"},
+				"X-Ms-Ratelimit-Remaining-Tenant-Reads":       {"42"},
+			},
+			want:   42,
+			wantOk: true,
+		},
+		{
+			name: "resource quotas",
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Resource": {"Microsoft.Compute/HighCostGet3Min;107,Microsoft.Compute/HighCostGet30Min;527"},
+			},
+			want:   107,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := remainingRequests(tt.header)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("remainingRequests() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{
+			name:   "none",
+			header: http.Header{},
+			want:   0,
+		},
+		{
+			name:   "seconds",
+			header: http.Header{"Retry-After": {"17"}},
+			want:   17 * time.Second,
+		},
+		{
+			name:   "http date",
+			header: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}},
+			want:   time.Minute,
+		},
+		{
+			name:   "milliseconds",
+			header: http.Header{"Retry-After": {"17"}, "Retry-After-Ms": {"250"}},
+			want:   250 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header, now); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_observe(t *testing.T) {
+	l := NewRateLimiter(10, 100)
+	now := time.Now()
+	b := &tokenBucket{tokens: 100, last: now}
+
+	l.observe(b, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Ms-Ratelimit-Remaining-Subscription-Reads": {"5"}},
+	}, now)
+	if b.tokens != 5 {
+		t.Errorf("tokens = %v, want 5", b.tokens)
+	}
+
+	l.observe(b, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"3"}},
+		Request:    &http.Request{URL: &url.URL{Host: "management.azure.com"}},
+	}, now)
+	if got := l.take(b, now.Add(time.Second)); got != 2*time.Second {
+		t.Errorf("take() while paused = %v, want 2s", got)
+	}
+	if got := l.take(b, now.Add(3*time.Second)); got != 0 {
+		t.Errorf("take() after pause = %v, want 0", got)
+	}
+}
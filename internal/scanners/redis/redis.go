@@ -16,6 +16,17 @@ type RedisScanner struct {
 	redisClient *armredis.Client
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "redis",
+		Description: "Azure Cache for Redis",
+		ResourceTypes: []string{
+			"Microsoft.Cache/Redis",
+		},
+		New: func() scanners.IAzureScanner { return &RedisScanner{} },
+	})
+}
+
 // Init - Initializes the RedisScanner
 func (c *RedisScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *RedisScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*redis.ID),
 			ServiceName:    *redis.Name,
+			ID:             *redis.ID,
 			Type:           *redis.Type,
 			Location:       *redis.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *RedisScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 }
 
 func (c *RedisScanner) listRedis(resourceGroupName string) ([]*armredis.ResourceInfo, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armredis.ResourceInfo](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Cache/Redis")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.redisClient.NewListBySubscriptionPager(nil)
+
+		redis := make([]*armredis.ResourceInfo, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			redis = append(redis, resp.Value...)
+		}
+		return redis, nil
+	}
+
 	pager := c.redisClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	redis := make([]*armredis.ResourceInfo, 0)
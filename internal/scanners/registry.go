@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScannerRegistration - Describes a scanner: the short name of its service (also the name
+// of its scan subcommand), the resource types it scans and its constructor. Several
+// scanners can share the same service name (e.g. mysql and mysql flexible servers).
+type ScannerRegistration struct {
+	Name          string
+	Description   string
+	ResourceTypes []string
+	New           func() IAzureScanner
+}
+
+var registry = []ScannerRegistration{}
+
+// RegisterScanner - Adds a scanner to the registry. Scanner packages call it from their init function.
+func RegisterScanner(r ScannerRegistration) {
+	if r.Name == "" || r.New == nil {
+		panic("scanners: RegisterScanner requires a name and a constructor")
+	}
+	registry = append(registry, r)
+}
+
+// RegisteredScanners - Returns the registered scanners sorted by service name
+func RegisteredScanners() []ScannerRegistration {
+	registrations := append([]ScannerRegistration{}, registry...)
+	sort.SliceStable(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+	return registrations
+}
+
+// ServiceNames - Returns the sorted names of the registered services
+func ServiceNames() []string {
+	names := []string{}
+	for _, r := range RegisteredScanners() {
+		if len(names) == 0 || names[len(names)-1] != r.Name {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// ServiceDescription - Returns the description of a registered service
+func ServiceDescription(name string) string {
+	for _, r := range registry {
+		if r.Name == name {
+			return r.Description
+		}
+	}
+	return ""
+}
+
+// IsRegisteredService - Returns true if a service with this (case insensitive) name is registered
+func IsRegisteredService(name string) bool {
+	for _, r := range registry {
+		if strings.EqualFold(r.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceRegistrations - Returns the registrations of the given services, or of every service when none is given
+func ServiceRegistrations(names ...string) []ScannerRegistration {
+	registrations := []ScannerRegistration{}
+	for _, r := range RegisteredScanners() {
+		if len(names) == 0 || containsFold(names, r.Name) {
+			registrations = append(registrations, r)
+		}
+	}
+	return registrations
+}
+
+// HasResourceType - Returns true if the scanner scans the resource type
+func (r ScannerRegistration) HasResourceType(resourceType string) bool {
+	return containsFold(r.ResourceTypes, resourceType)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
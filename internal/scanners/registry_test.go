@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+
+	registry = []ScannerRegistration{}
+	newScanner := func() IAzureScanner { return nil }
+	RegisterScanner(ScannerRegistration{Name: "psql", Description: "Azure Database for PostgreSQL", ResourceTypes: []string{"Microsoft.DBforPostgreSQL/flexibleServers"}, New: newScanner})
+	RegisterScanner(ScannerRegistration{Name: "kv", Description: "Azure Key Vault", ResourceTypes: []string{"Microsoft.KeyVault/vaults"}, New: newScanner})
+	RegisterScanner(ScannerRegistration{Name: "psql", Description: "Azure Database for PostgreSQL", ResourceTypes: []string{"Microsoft.DBforPostgreSQL/servers"}, New: newScanner})
+
+	if got, want := ServiceNames(), []string{"kv", "psql"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ServiceNames() = %v, want %v", got, want)
+	}
+	if got := ServiceDescription("kv"); got != "Azure Key Vault" {
+		t.Errorf("ServiceDescription() = %v, want Azure Key Vault", got)
+	}
+	if !IsRegisteredService("KV") || IsRegisteredService("st") {
+		t.Errorf("IsRegisteredService() returned unexpected results")
+	}
+
+	psql := ServiceRegistrations("psql")
+	if len(psql) != 2 {
+		t.Fatalf("ServiceRegistrations() returned %d registrations, want 2", len(psql))
+	}
+	if !psql[0].HasResourceType("microsoft.dbforpostgresql/flexibleservers") || psql[0].HasResourceType("Microsoft.DBforPostgreSQL/servers") {
+		t.Errorf("ScannerRegistration.HasResourceType() returned unexpected results")
+	}
+	if got := len(ServiceRegistrations()); got != 3 {
+		t.Errorf("ServiceRegistrations() returned %d registrations, want 3", got)
+	}
+}
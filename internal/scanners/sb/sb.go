@@ -16,6 +16,17 @@ type ServiceBusScanner struct {
 	servicebusClient *armservicebus.NamespacesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "sb",
+		Description: "Azure Service Bus",
+		ResourceTypes: []string{
+			"Microsoft.ServiceBus/namespaces",
+		},
+		New: func() scanners.IAzureScanner { return &ServiceBusScanner{} },
+	})
+}
+
 // Init - Initializes the ServiceBusScanner
 func (a *ServiceBusScanner) Init(config *scanners.ScannerConfig) error {
 	a.config = config
@@ -41,8 +52,9 @@ func (c *ServiceBusScanner) Scan(resourceGroupName string, scanContext *scanners
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*servicebus.ID),
 			ServiceName:    *servicebus.Name,
+			ID:             *servicebus.ID,
 			Type:           *servicebus.Type,
 			Location:       *servicebus.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *ServiceBusScanner) Scan(resourceGroupName string, scanContext *scanners
 }
 
 func (c *ServiceBusScanner) listServiceBus(resourceGroupName string) ([]*armservicebus.SBNamespace, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armservicebus.SBNamespace](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.ServiceBus/namespaces")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.servicebusClient.NewListPager(nil)
+
+		namespaces := make([]*armservicebus.SBNamespace, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, resp.Value...)
+		}
+		return namespaces, nil
+	}
+
 	pager := c.servicebusClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	namespaces := make([]*armservicebus.SBNamespace, 0)
@@ -13,7 +13,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	arg "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
-	"github.com/rs/zerolog/log"
 )
 
 type (
@@ -23,16 +22,27 @@ type (
 		Cred           azcore.TokenCredential
 		SubscriptionID string
 		ClientOptions  *arm.ClientOptions
+		Snapshot       *Snapshot
+		Pool           *WorkerPool
 	}
 
 	// ScanContext - Struct for Scanner Context
 	ScanContext struct {
 		PrivateEndpoints    map[string]bool
 		DiagnosticsSettings map[string]bool
+		// WorkspaceResources - Number of resources of the subscription sending their data to each Log Analytics workspace, by lowercase workspace ID
+		WorkspaceResources  map[string]int
 		PublicIPs		   	map[string]*armnetwork.PublicIPAddress
+		CustomRules         *CustomRules
+		Filter              *RuleFilter
+		// VirtualNetworkGateways - Virtual Network Gateways listed once for the scanners that share their resource type
+		VirtualNetworkGateways *VirtualNetworkGateways
+		// Recording - Set while capturing a snapshot: scanners list their resources but no rule is evaluated
+		Recording           bool
 	}
 
-	// IAzureScanner - Interface for all Azure Scanners
+	// IAzureScanner - Interface for all Azure Scanners. Scan lists the resources of the whole
+	// subscription when resourceGroupName is empty.
 	IAzureScanner interface {
 		Init(config *ScannerConfig) error
 		GetRules() map[string]AzureRule
@@ -46,6 +56,7 @@ type (
 		Location       string
 		Type           string
 		ServiceName    string
+		ID             string
 		Rules          map[string]AzureRuleResult
 	}
 
@@ -61,21 +72,25 @@ type (
 	}
 
 	AzureRuleResult struct {
-		Id          string
-		Category    string
-		Subcategory string
-		Description string
-		Severity    string
-		Learn       string
-		Result      string
-		Field       OverviewField
-		IsBroken    bool
+		Id            string
+		Category      string
+		Subcategory   string
+		Description   string
+		Severity      string
+		Learn         string
+		Result        string
+		Field         OverviewField
+		IsBroken      bool
+		IsSuppressed  bool
+		Justification string
 	}
 
 	RuleEngine struct{}
 
+	// GraphQuery - Runs Resource Graph queries with the cloud and policies of ClientOptions
 	GraphQuery struct {
-		client *arg.Client
+		ClientOptions *arm.ClientOptions
+		client        *arg.Client
 	}
 
 	GraphResult struct {
@@ -114,11 +129,21 @@ func (e *RuleEngine) EvaluateRule(rule AzureRule, target interface{}, scanContex
 
 func (e *RuleEngine) EvaluateRules(rules map[string]AzureRule, target interface{}, scanContext *ScanContext) map[string]AzureRuleResult {
 	results := map[string]AzureRuleResult{}
+	if scanContext != nil && scanContext.Recording {
+		return results
+	}
 
 	for k, rule := range rules {
+		if scanContext != nil && !scanContext.Filter.Matches(rule) {
+			continue
+		}
 		results[k] = e.EvaluateRule(rule, target, scanContext)
 	}
 
+	if scanContext != nil {
+		scanContext.CustomRules.evaluate(e, target, scanContext, results)
+	}
+
 	return results
 }
 
@@ -185,6 +210,11 @@ func (r AzureServiceResult) GetHeaders() []string {
 	}
 }
 
+// GetResourceGroupFromResourceID - Returns the resource group name of a resource id
+func GetResourceGroupFromResourceID(resourceID string) string {
+	return resourceIDSegment(resourceID, "resourceGroups")
+}
+
 func ParseLocation(location string) string {
 	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
 }
@@ -198,6 +228,20 @@ func MaskSubscriptionID(subscriptionID string, mask bool) string {
 	return fmt.Sprintf("xxxxxxxx-xxxx-xxxx-xxxx-xxxxx%s", subscriptionID[29:])
 }
 
+// MaskResourceID - Masks the subscription id segment of a resource id
+func MaskResourceID(resourceID, subscriptionID string, mask bool) string {
+	if !mask || subscriptionID == "" {
+		return resourceID
+	}
+
+	i := strings.Index(strings.ToLower(resourceID), strings.ToLower(subscriptionID))
+	if i < 0 {
+		return resourceID
+	}
+
+	return resourceID[:i] + MaskSubscriptionID(subscriptionID, mask) + resourceID[i+len(subscriptionID):]
+}
+
 const (
 	SeverityHigh   = "High"
 	SeverityMedium = "Medium"
@@ -221,6 +265,7 @@ const (
 	RulesSubcategoryOperationalExcellenceCAF               = "Naming Convention (CAF)"
 	RulesSubcategoryOperationalExcellenceTags              = "Tags"
 	RulesSubcategoryOperationalExcellenceRetentionPolicies = "Retention Policies"
+	RulesSubcategoryOperationalExcellenceMonitoring        = "Monitoring"
 
 	RulesSubcategorySecurityNetworkSecurityGroups = "Network Security Groups"
 	RulesSubcategorySecuritySSL                   = "SSL"
@@ -231,11 +276,17 @@ const (
 	RulesSubcategorySecurityFirewall              = "Firewall"
 	RulesSubcategorySecurityIdentity              = "Identity and Access Control"
 	RulesSubcategorySecurityNetworking            = "Networking"
+	RulesSubcategorySecurityEncryption            = "Encryption"
+
+	RulesSubcategoryCostOptimizationUnusedResources = "Unused Resources"
+	RulesSubcategoryCostOptimizationStorage         = "Storage"
 
 	RulesSubcategoryPerformanceEfficienccyNetworking = "Networking"
 )
 
-func (q *GraphQuery) Query(ctx context.Context, cred azcore.TokenCredential, query string, subscriptionIDs []*string) *GraphResult {
+// Query - Runs a Resource Graph query in the given subscriptions (or in every accessible
+// subscription when subscriptionIDs is nil), following the skip token of each page
+func (q *GraphQuery) Query(ctx context.Context, cred azcore.TokenCredential, query string, subscriptionIDs []*string) (*GraphResult, error) {
 	format := arg.ResultFormatObjectArray
 	request := arg.QueryRequest{
 		Subscriptions: subscriptionIDs,
@@ -246,23 +297,29 @@ func (q *GraphQuery) Query(ctx context.Context, cred azcore.TokenCredential, que
 	}
 
 	if q.client == nil {
-		client, err := arg.NewClient(cred, nil)
+		client, err := arg.NewClient(cred, q.ClientOptions)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to create Resource Graph client")
-			return nil
+			return nil, err
 		}
 		q.client = client
 	}
 
-	// Run the query and get the results
-	results, err := q.client.Resources(ctx, request, nil)
-	if err == nil {
-		result := GraphResult{}
+	result := GraphResult{
+		Data: []interface{}{},
+	}
+	for {
+		results, err := q.client.Resources(ctx, request, nil)
+		if err != nil {
+			return nil, err
+		}
 		result.Count = *results.TotalRecords
-		result.Data = results.Data.([]interface{})
-		return &result
-	} else {
-		log.Fatal().Err(err).Msg("Failed to run Resource Graph query")
-		return nil
+		if data, ok := results.Data.([]interface{}); ok {
+			result.Data = append(result.Data, data...)
+		}
+
+		if results.SkipToken == nil || *results.SkipToken == "" {
+			return &result, nil
+		}
+		request.Options.SkipToken = results.SkipToken
 	}
 }
@@ -16,6 +16,17 @@ type SignalRScanner struct {
 	signalrClient *armsignalr.Client
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "sigr",
+		Description: "Azure SignalR",
+		ResourceTypes: []string{
+			"Microsoft.SignalRService/SignalR",
+		},
+		New: func() scanners.IAzureScanner { return &SignalRScanner{} },
+	})
+}
+
 // Init - Initializes the SignalRScanner
 func (c *SignalRScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *SignalRScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*signalr.ID),
 			ServiceName:    *signalr.Name,
+			ID:             *signalr.ID,
 			Type:           *signalr.Type,
 			Location:       *signalr.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *SignalRScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 }
 
 func (c *SignalRScanner) listSignalR(resourceGroupName string) ([]*armsignalr.ResourceInfo, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armsignalr.ResourceInfo](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.SignalRService/SignalR")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.signalrClient.NewListBySubscriptionPager(nil)
+
+		signalrs := make([]*armsignalr.ResourceInfo, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			signalrs = append(signalrs, resp.Value...)
+		}
+		return signalrs, nil
+	}
+
 	pager := c.signalrClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	signalrs := make([]*armsignalr.ResourceInfo, 0)
@@ -0,0 +1,252 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SnapshotManifestFileName - Name of the manifest file, which is not loaded as a resource payload
+const SnapshotManifestFileName = "manifest.json"
+
+type (
+	// Snapshot - Resources loaded from exported ARM or Resource Graph JSON payloads
+	Snapshot struct {
+		resources []snapshotResource
+	}
+
+	snapshotResource struct {
+		id             string
+		resourceType   string
+		subscriptionID string
+		resourceGroup  string
+		raw            json.RawMessage
+	}
+
+	// snapshotEnvelope - Shapes of the supported JSON payloads: Resource Graph exports
+	// (data), ARM list responses (value), ARM batch responses (responses) and single
+	// ARM GET bodies (id, type).
+	snapshotEnvelope struct {
+		Data      []json.RawMessage `json:"data"`
+		Value     []json.RawMessage `json:"value"`
+		Responses []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"responses"`
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+)
+
+// LoadSnapshot - Loads every JSON file found in path (a file or a directory) into a Snapshot
+func LoadSnapshot(path string) (*Snapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{}
+	seen := map[string]int{}
+
+	load := func(file string) error {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		items, err := parseSnapshotPayload(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse snapshot file %s: %w", file, err)
+		}
+		for _, item := range items {
+			r, ok := newSnapshotResource(item)
+			if !ok {
+				log.Debug().Msgf("Skipping snapshot item without id or type in %s", file)
+				continue
+			}
+			if i, exists := seen[r.id]; exists {
+				s.resources[i] = r
+				continue
+			}
+			seen[r.id] = len(s.resources)
+			s.resources = append(s.resources, r)
+		}
+		return nil
+	}
+
+	if !info.IsDir() {
+		err = load(path)
+	} else {
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".json") || d.Name() == SnapshotManifestFileName {
+				return nil
+			}
+			return load(p)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("Loaded %d resources from snapshot %s", len(s.resources), path)
+	return s, nil
+}
+
+func parseSnapshotPayload(content []byte) ([]json.RawMessage, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "[") {
+		items := []json.RawMessage{}
+		err := json.Unmarshal(content, &items)
+		return items, err
+	}
+
+	envelope := snapshotEnvelope{}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case envelope.Data != nil:
+		return envelope.Data, nil
+	case envelope.Value != nil:
+		return envelope.Value, nil
+	case envelope.Responses != nil:
+		items := []json.RawMessage{}
+		for _, r := range envelope.Responses {
+			if len(r.Content) == 0 {
+				continue
+			}
+			content, err := parseSnapshotPayload(r.Content)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, content...)
+		}
+		return items, nil
+	case envelope.ID != "" && envelope.Type != "":
+		return []json.RawMessage{content}, nil
+	}
+	return []json.RawMessage{}, nil
+}
+
+func newSnapshotResource(raw json.RawMessage) (snapshotResource, bool) {
+	envelope := snapshotEnvelope{}
+	if err := json.Unmarshal(raw, &envelope); err != nil || envelope.ID == "" || envelope.Type == "" {
+		return snapshotResource{}, false
+	}
+
+	return snapshotResource{
+		id:             strings.ToLower(envelope.ID),
+		resourceType:   strings.ToLower(envelope.Type),
+		subscriptionID: strings.ToLower(resourceIDSegment(envelope.ID, "subscriptions")),
+		resourceGroup:  resourceIDSegment(envelope.ID, "resourceGroups"),
+		raw:            raw,
+	}, true
+}
+
+// resourceIDSegment - Returns the value following the given key in a resource id
+func resourceIDSegment(resourceID, key string) string {
+	parts := strings.Split(resourceID, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if strings.EqualFold(parts[i], key) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// Subscriptions - Returns the subscription ids found in the snapshot
+func (s *Snapshot) Subscriptions() []string {
+	seen := map[string]bool{}
+	subscriptions := []string{}
+	for _, r := range s.resources {
+		if r.subscriptionID != "" && !seen[r.subscriptionID] {
+			seen[r.subscriptionID] = true
+			subscriptions = append(subscriptions, r.subscriptionID)
+		}
+	}
+	sort.Strings(subscriptions)
+	return subscriptions
+}
+
+// ResourceGroups - Returns the resource groups of a subscription found in the snapshot
+func (s *Snapshot) ResourceGroups(subscriptionID string) []string {
+	seen := map[string]bool{}
+	resourceGroups := []string{}
+	for _, r := range s.resources {
+		key := strings.ToLower(r.resourceGroup)
+		if r.subscriptionID == strings.ToLower(subscriptionID) && r.resourceGroup != "" && !seen[key] {
+			seen[key] = true
+			resourceGroups = append(resourceGroups, r.resourceGroup)
+		}
+	}
+	sort.Strings(resourceGroups)
+	return resourceGroups
+}
+
+// ResourceTypes - Counts the resources of each type in a subscription (or one of its
+// resource groups when resourceGroupName is not empty) found in the snapshot
+func (s *Snapshot) ResourceTypes(subscriptionID, resourceGroupName string) ResourceInventory {
+	inventory := ResourceInventory{}
+	for _, r := range s.resources {
+		if r.subscriptionID != strings.ToLower(subscriptionID) ||
+			(resourceGroupName != "" && !strings.EqualFold(r.resourceGroup, resourceGroupName)) {
+			continue
+		}
+		inventory[r.resourceType]++
+	}
+	return inventory
+}
+
+func (s *Snapshot) find(subscriptionID, resourceGroupName, resourceType string, match func(r snapshotResource) bool) []json.RawMessage {
+	items := []json.RawMessage{}
+	for _, r := range s.resources {
+		if r.resourceType != strings.ToLower(resourceType) ||
+			r.subscriptionID != strings.ToLower(subscriptionID) ||
+			(resourceGroupName != "" && !strings.EqualFold(r.resourceGroup, resourceGroupName)) {
+			continue
+		}
+		if match == nil || match(r) {
+			items = append(items, r.raw)
+		}
+	}
+	return items
+}
+
+// ListFromSnapshot - Returns the resources of a given type in a subscription (or one of
+// its resource groups when resourceGroupName is not empty) unmarshalled into the ARM model T
+func ListFromSnapshot[T any](s *Snapshot, subscriptionID, resourceGroupName, resourceType string) ([]*T, error) {
+	return unmarshalSnapshotItems[T](s.find(subscriptionID, resourceGroupName, resourceType, nil))
+}
+
+// ListChildrenFromSnapshot - Returns the child resources of a given type (e.g. Microsoft.Sql/servers/databases)
+// belonging to the parent resource named parentName
+func ListChildrenFromSnapshot[T any](s *Snapshot, subscriptionID, resourceGroupName, parentName, resourceType string) ([]*T, error) {
+	parts := strings.Split(strings.ToLower(resourceType), "/")
+	segment := fmt.Sprintf("/%s/%s/", strings.ToLower(parentName), parts[len(parts)-1])
+	return unmarshalSnapshotItems[T](s.find(subscriptionID, resourceGroupName, resourceType, func(r snapshotResource) bool {
+		return strings.Contains(r.id, segment)
+	}))
+}
+
+func unmarshalSnapshotItems[T any](items []json.RawMessage) ([]*T, error) {
+	results := make([]*T, 0, len(items))
+	for _, item := range items {
+		v := new(T)
+		if err := json.Unmarshal(item, v); err != nil {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// SnapshotRecorder - Pipeline policy that persists every ARM response containing resources
+	// into a directory that can later be loaded with LoadSnapshot
+	SnapshotRecorder struct {
+		dir      string
+		mutex    sync.Mutex
+		manifest SnapshotManifest
+	}
+
+	// SnapshotManifest - Describes the files captured by a SnapshotRecorder
+	SnapshotManifest struct {
+		CreatedAt     time.Time              `json:"createdAt"`
+		AzqrVersion   string                 `json:"azqrVersion"`
+		Subscriptions []string               `json:"subscriptions"`
+		Files         []SnapshotManifestFile `json:"files"`
+	}
+
+	// SnapshotManifestFile - A captured response and the number of resources it contains
+	SnapshotManifestFile struct {
+		Name      string `json:"name"`
+		URL       string `json:"url"`
+		Resources int    `json:"resources"`
+	}
+)
+
+var snapshotFileNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// NewSnapshotRecorder - Creates a SnapshotRecorder writing to dir
+func NewSnapshotRecorder(dir string) (*SnapshotRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &SnapshotRecorder{
+		dir: dir,
+		manifest: SnapshotManifest{
+			Files: []SnapshotManifestFile{},
+		},
+	}, nil
+}
+
+// Do - Implements policy.Policy
+func (r *SnapshotRecorder) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	method := req.Raw().Method
+	if method != http.MethodGet && method != http.MethodPost {
+		return resp, nil
+	}
+
+	// Payload buffers the body, so the caller can still read it.
+	body, err := runtime.Payload(resp)
+	if err != nil {
+		return resp, err
+	}
+	r.record(req.Raw().URL.Path, body)
+
+	return resp, nil
+}
+
+func (r *SnapshotRecorder) record(path string, body []byte) {
+	items, err := parseSnapshotPayload(body)
+	if err != nil {
+		return
+	}
+
+	resources := 0
+	for _, item := range items {
+		if _, ok := newSnapshotResource(item); ok {
+			resources++
+		}
+	}
+	if resources == 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	name := fmt.Sprintf("%05d_%s.json", len(r.manifest.Files)+1, snapshotFileName(path))
+	if err := os.WriteFile(filepath.Join(r.dir, name), body, 0644); err != nil {
+		log.Fatal().Err(err).Msg("Failed to write snapshot file")
+	}
+
+	r.manifest.Files = append(r.manifest.Files, SnapshotManifestFile{
+		Name:      name,
+		URL:       path,
+		Resources: resources,
+	})
+	log.Debug().Msgf("Recorded %d resources from %s", resources, path)
+}
+
+// WriteManifest - Writes the manifest of the captured files
+func (r *SnapshotRecorder) WriteManifest(version string, subscriptions []string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.manifest.CreatedAt = time.Now().UTC()
+	r.manifest.AzqrVersion = version
+	r.manifest.Subscriptions = subscriptions
+
+	content, err := json.MarshalIndent(r.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, SnapshotManifestFileName), content, 0644)
+}
+
+// snapshotFileName - Builds a readable file name from the provider part of a request path
+func snapshotFileName(path string) string {
+	if i := strings.LastIndex(strings.ToLower(path), "/providers/"); i >= 0 {
+		path = path[i+len("/providers/"):]
+	}
+	name := strings.Trim(snapshotFileNameRegexp.ReplaceAllString(path, "_"), "_")
+	if name == "" {
+		name = "response"
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	return name
+}
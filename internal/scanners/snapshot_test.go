@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+const (
+	snapshotGraphExport = `{"count": 2, "data": [
+		{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworks/vnet-1", "name": "vnet-1", "type": "microsoft.network/virtualnetworks", "location": "westeurope"},
+		{"id": "/subscriptions/sub1/resourceGroups/RG2/providers/Microsoft.Network/virtualNetworks/vnet-2", "name": "vnet-2", "type": "microsoft.network/virtualnetworks", "location": "westeurope"}
+	]}`
+	snapshotListResponse = `{"value": [
+		{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Sql/servers/sql-1/databases/db-1", "name": "db-1", "type": "Microsoft.Sql/servers/databases"},
+		{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Sql/servers/sql-2/databases/db-2", "name": "db-2", "type": "Microsoft.Sql/servers/databases"}
+	]}`
+	snapshotGetBody = `{"id": "/subscriptions/sub2/resourceGroups/RG3/providers/Microsoft.Network/virtualNetworks/vnet-3", "name": "vnet-3", "type": "Microsoft.Network/virtualNetworks"}`
+	snapshotArray   = `[{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworks/vnet-1", "name": "vnet-1-updated", "type": "Microsoft.Network/virtualNetworks"}]`
+)
+
+func writeSnapshot(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"graph.json":             snapshotGraphExport,
+		"sql/databases.json":     snapshotListResponse,
+		"vnet-3.json":            snapshotGetBody,
+		"updates.json":           snapshotArray,
+		SnapshotManifestFileName: `{"id": "ignored", "type": "ignored"}`,
+		"notes.txt":              "not json",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	s, err := LoadSnapshot(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	if got, want := s.Subscriptions(), []string{"sub1", "sub2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot.Subscriptions() = %v, want %v", got, want)
+	}
+
+	if got, want := s.ResourceGroups("SUB1"), []string{"RG1", "RG2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot.ResourceGroups() = %v, want %v", got, want)
+	}
+
+	vnets, err := ListFromSnapshot[armnetwork.VirtualNetwork](s, "sub1", "rg1", "Microsoft.Network/virtualNetworks")
+	if err != nil {
+		t.Fatalf("ListFromSnapshot() error = %v", err)
+	}
+	if len(vnets) != 1 || *vnets[0].Name != "vnet-1-updated" {
+		t.Errorf("ListFromSnapshot() = %v, want vnet-1-updated", vnets)
+	}
+
+	vnets, err = ListFromSnapshot[armnetwork.VirtualNetwork](s, "sub1", "", "Microsoft.Network/virtualNetworks")
+	if err != nil {
+		t.Fatalf("ListFromSnapshot() error = %v", err)
+	}
+	if len(vnets) != 2 {
+		t.Errorf("ListFromSnapshot() returned %d resources, want 2", len(vnets))
+	}
+
+	databases, err := ListChildrenFromSnapshot[armnetwork.SubResource](s, "sub1", "RG1", "sql-2", "Microsoft.Sql/servers/databases")
+	if err != nil {
+		t.Fatalf("ListChildrenFromSnapshot() error = %v", err)
+	}
+	if len(databases) != 1 || *databases[0].ID != "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Sql/servers/sql-2/databases/db-2" {
+		t.Errorf("ListChildrenFromSnapshot() = %v, want db-2", databases)
+	}
+}
+
+func TestSnapshotRecorder(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewSnapshotRecorder(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotRecorder() error = %v", err)
+	}
+
+	r.record("/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworks", []byte(snapshotGraphExport))
+	r.record("/batch", []byte(`{"responses": [
+		{"httpStatusCode": 200, "content": {"value": [
+			{"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Network/virtualNetworks/vnet-1/providers/microsoft.insights/diagnosticSettings/diag", "name": "diag", "type": "Microsoft.Insights/diagnosticSettings"}
+		]}},
+		{"httpStatusCode": 200, "content": {"value": []}}
+	]}`))
+	r.record("/subscriptions", []byte(`{"value": [{"id": "/subscriptions/sub1", "subscriptionId": "sub1"}]}`))
+
+	if err := r.WriteManifest("test", []string{"sub1"}); err != nil {
+		t.Fatalf("SnapshotRecorder.WriteManifest() error = %v", err)
+	}
+
+	if got := len(r.manifest.Files); got != 2 {
+		t.Errorf("SnapshotRecorder recorded %d files, want 2", got)
+	}
+	if got, want := r.manifest.Files[0].Name, "00001_Microsoft.Network_virtualNetworks.json"; got != want {
+		t.Errorf("SnapshotRecorder file name = %s, want %s", got, want)
+	}
+
+	s, err := LoadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if got := len(s.resources); got != 3 {
+		t.Errorf("LoadSnapshot() loaded %d resources, want 3", got)
+	}
+}
@@ -17,6 +17,18 @@ type SQLScanner struct {
 	sqlDatabasedClient *armsql.DatabasesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "sql",
+		Description: "Azure SQL Database",
+		ResourceTypes: []string{
+			"Microsoft.Sql/servers",
+			"Microsoft.Sql/servers/databases",
+		},
+		New: func() scanners.IAzureScanner { return &SQLScanner{} },
+	})
+}
+
 // Init - Initializes the SQLScanner
 func (c *SQLScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -50,14 +62,15 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*sql.ID),
 			ServiceName:    *sql.Name,
+			ID:             *sql.ID,
 			Type:           *sql.Type,
 			Location:       *sql.Location,
 			Rules:          rr,
 		})
 
-		databases, err := c.listDatabases(resourceGroupName, *sql.Name)
+		databases, err := c.listDatabases(scanners.GetResourceGroupFromResourceID(*sql.ID), *sql.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -66,8 +79,9 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 
 			results = append(results, scanners.AzureServiceResult{
 				SubscriptionID: c.config.SubscriptionID,
-				ResourceGroup:  resourceGroupName,
+				ResourceGroup:  scanners.GetResourceGroupFromResourceID(*database.ID),
 				ServiceName:    *database.Name,
+				ID:             *database.ID,
 				Type:           *database.Type,
 				Location:       *database.Location,
 				Rules:          rr,
@@ -79,6 +93,24 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 }
 
 func (c *SQLScanner) listSQL(resourceGroupName string) ([]*armsql.Server, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armsql.Server](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Sql/servers")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.sqlClient.NewListPager(nil)
+
+		servers := make([]*armsql.Server, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, resp.Value...)
+		}
+		return servers, nil
+	}
+
 	pager := c.sqlClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	servers := make([]*armsql.Server, 0)
@@ -93,6 +125,10 @@ func (c *SQLScanner) listSQL(resourceGroupName string) ([]*armsql.Server, error)
 }
 
 func (c *SQLScanner) listDatabases(resourceGroupName, serverName string) ([]*armsql.Database, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListChildrenFromSnapshot[armsql.Database](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, serverName, "Microsoft.Sql/servers/databases")
+	}
+
 	pager := c.sqlDatabasedClient.NewListByServerPager(resourceGroupName, serverName, nil)
 
 	databases := make([]*armsql.Database, 0)
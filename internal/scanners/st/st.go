@@ -16,6 +16,17 @@ type StorageScanner struct {
 	storageClient *armstorage.AccountsClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "st",
+		Description: "Azure Storage",
+		ResourceTypes: []string{
+			"Microsoft.Storage/storageAccounts",
+		},
+		New: func() scanners.IAzureScanner { return &StorageScanner{} },
+	})
+}
+
 // Init - Initializes the StorageScanner
 func (c *StorageScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *StorageScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*storage.ID),
 			ServiceName:    *storage.Name,
+			ID:             *storage.ID,
 			Type:           *storage.Type,
 			Location:       *storage.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *StorageScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 }
 
 func (c *StorageScanner) listStorage(resourceGroupName string) ([]*armstorage.Account, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armstorage.Account](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Storage/storageAccounts")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.storageClient.NewListPager(nil)
+
+		staccounts := make([]*armstorage.Account, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			staccounts = append(staccounts, resp.Value...)
+		}
+		return staccounts, nil
+	}
+
 	pager := c.storageClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	staccounts := make([]*armstorage.Account, 0)
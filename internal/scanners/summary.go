@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResultSummary - Counts of the broken (and not suppressed) rules of a scan, and of the scan errors
+type ResultSummary struct {
+	Resources  int            `json:"resources"`
+	Broken     int            `json:"broken"`
+	Suppressed int            `json:"suppressed"`
+	Severity   map[string]int `json:"severity"`
+	Category   map[string]int `json:"category"`
+	Errors     int            `json:"errors"`
+	Partial    bool           `json:"partial,omitempty"`
+}
+
+// severityRank - Higher is more severe
+var severityRank = map[string]int{
+	SeverityLow:    1,
+	SeverityMedium: 2,
+	SeverityHigh:   3,
+}
+
+// ParseSeverity - Returns the canonical severity name (High, Medium or Low) of a case insensitive value
+func ParseSeverity(severity string) (string, error) {
+	for s := range severityRank {
+		if strings.EqualFold(s, severity) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported severity: %s (use %s, %s or %s)", severity, SeverityHigh, SeverityMedium, SeverityLow)
+}
+
+// SeverityAtLeast - Returns true if severity is equal to or more severe than threshold
+func SeverityAtLeast(severity, threshold string) bool {
+	return severityRank[severity] >= severityRank[threshold] && severityRank[severity] > 0
+}
+
+// SummarizeResults - Counts the broken rules by Severity and Category
+func SummarizeResults(results []AzureServiceResult) ResultSummary {
+	summary := ResultSummary{
+		Resources: len(results),
+		Severity:  map[string]int{},
+		Category:  map[string]int{},
+	}
+
+	for _, r := range results {
+		for _, rule := range r.Rules {
+			if rule.IsSuppressed {
+				summary.Suppressed++
+			}
+			if !rule.IsBroken {
+				continue
+			}
+			summary.Broken++
+			summary.Severity[rule.Severity]++
+			summary.Category[rule.Category]++
+		}
+	}
+
+	return summary
+}
+
+// HasBrokenAtLeast - Returns true if any broken rule is equal to or more severe than threshold
+func (s ResultSummary) HasBrokenAtLeast(threshold string) bool {
+	for severity, count := range s.Severity {
+		if count > 0 && SeverityAtLeast(severity, threshold) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeResults(t *testing.T) {
+	results := []AzureServiceResult{
+		{
+			Rules: map[string]AzureRuleResult{
+				"kv-001": {Severity: SeverityMedium, Category: RulesCategoryReliability, IsBroken: true},
+				"kv-002": {Severity: SeverityHigh, Category: RulesCategorySecurity, IsSuppressed: true},
+				"kv-003": {Severity: SeverityLow, Category: RulesCategoryReliability},
+			},
+		},
+		{
+			Rules: map[string]AzureRuleResult{
+				"st-001": {Severity: SeverityLow, Category: RulesCategorySecurity, IsBroken: true},
+			},
+		},
+	}
+
+	got := SummarizeResults(results)
+	want := ResultSummary{
+		Resources:  2,
+		Broken:     2,
+		Suppressed: 1,
+		Severity:   map[string]int{SeverityMedium: 1, SeverityLow: 1},
+		Category:   map[string]int{RulesCategoryReliability: 1, RulesCategorySecurity: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeResults() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		threshold string
+		want      bool
+	}{
+		{SeverityHigh, false},
+		{SeverityMedium, true},
+		{SeverityLow, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			if got := want.HasBrokenAtLeast(tt.threshold); got != tt.want {
+				t.Errorf("ResultSummary.HasBrokenAtLeast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if got, err := ParseSeverity("high"); err != nil || got != SeverityHigh {
+		t.Errorf("ParseSeverity() = %v, %v, want %v", got, err, SeverityHigh)
+	}
+	if _, err := ParseSeverity("critical"); err == nil {
+		t.Errorf("ParseSeverity() expected an error")
+	}
+}
@@ -16,6 +16,17 @@ type VirtualMachineScanner struct {
 	client *armcompute.VirtualMachinesClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "vm",
+		Description: "Azure Virtual Machines",
+		ResourceTypes: []string{
+			"Microsoft.Compute/virtualMachines",
+		},
+		New: func() scanners.IAzureScanner { return &VirtualMachineScanner{} },
+	})
+}
+
 // Init - Initializes the VirtualMachineScanner
 func (c *VirtualMachineScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *VirtualMachineScanner) Scan(resourceGroupName string, scanContext *scan
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *VirtualMachineScanner) Scan(resourceGroupName string, scanContext *scan
 }
 
 func (c *VirtualMachineScanner) list(resourceGroupName string) ([]*armcompute.VirtualMachine, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcompute.VirtualMachine](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Compute/virtualMachines")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListAllPager(nil)
+
+		vms := make([]*armcompute.VirtualMachine, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			vms = append(vms, resp.Value...)
+		}
+		return vms, nil
+	}
+
 	pager := c.client.NewListPager(resourceGroupName, nil)
 
 	vms := make([]*armcompute.VirtualMachine, 0)
@@ -0,0 +1,222 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package vmss
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+// GetRules - Returns the rules for the VirtualMachineScaleSetScanner
+func (a *VirtualMachineScaleSetScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"vmss-001": {
+			Id:          "vmss-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "Virtual Machine Scale Set should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*armcompute.VirtualMachineScaleSet)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/azure-monitor/essentials/diagnostic-settings",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"vmss-002": {
+			Id:          "vmss-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityAvailabilityZones,
+			Description: "Virtual Machine Scale Set should have availability zones enabled",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				hasZones := len(v.Zones) > 1
+				return !hasZones, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-use-availability-zones",
+			Field: scanners.OverviewFieldAZ,
+		},
+		"vmss-003": {
+			Id:          "vmss-003",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+			Description: "Virtual Machine Scale Set should have a SLA",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				sla := "99.95%"
+				if len(v.Zones) > 1 {
+					sla = "99.99%"
+				}
+				return false, sla
+			},
+			Url:   "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
+			Field: scanners.OverviewFieldSLA,
+		},
+		"vmss-004": {
+			Id:          "vmss-004",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityAvailabilityZones,
+			Description: "Virtual Machine Scale Set should spread its instances evenly across zones",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				if len(v.Zones) <= 1 {
+					return false, ""
+				}
+				balanced := v.Properties != nil && v.Properties.ZoneBalance != nil && *v.Properties.ZoneBalance
+				return !balanced, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-use-availability-zones#zone-balancing",
+		},
+		"vmss-005": {
+			Id:          "vmss-005",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryReliability,
+			Description: "Virtual Machine Scale Set should use Flexible orchestration mode",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				mode := armcompute.OrchestrationModeUniform
+				if v.Properties != nil && v.Properties.OrchestrationMode != nil {
+					mode = *v.Properties.OrchestrationMode
+				}
+				return mode != armcompute.OrchestrationModeFlexible, string(mode)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-orchestration-modes",
+		},
+		"vmss-006": {
+			Id:          "vmss-006",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryMaintenance,
+			Description: "Virtual Machine Scale Set should use an Automatic or Rolling upgrade policy",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				mode := armcompute.UpgradeModeManual
+				if v.Properties != nil && v.Properties.UpgradePolicy != nil && v.Properties.UpgradePolicy.Mode != nil {
+					mode = *v.Properties.UpgradePolicy.Mode
+				}
+				return mode == armcompute.UpgradeModeManual, string(mode)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-upgrade-policy",
+		},
+		"vmss-007": {
+			Id:          "vmss-007",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryMaintenance,
+			Description: "Virtual Machine Scale Set should enable automatic OS image upgrades",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				enabled := v.Properties != nil &&
+					v.Properties.UpgradePolicy != nil &&
+					v.Properties.UpgradePolicy.AutomaticOSUpgradePolicy != nil &&
+					v.Properties.UpgradePolicy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade != nil &&
+					*v.Properties.UpgradePolicy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade
+				return !enabled, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-automatic-upgrade",
+		},
+		"vmss-008": {
+			Id:          "vmss-008",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityMonitoring,
+			Description: "Virtual Machine Scale Set should monitor its instances with the Application Health extension or a load balancer health probe",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				return !hasHealthMonitoring(v), ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-health-extension",
+		},
+		"vmss-009": {
+			Id:          "vmss-009",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryReliability,
+			Description: "Virtual Machine Scale Set should enable automatic instance repairs",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				enabled := v.Properties != nil &&
+					v.Properties.AutomaticRepairsPolicy != nil &&
+					v.Properties.AutomaticRepairsPolicy.Enabled != nil &&
+					*v.Properties.AutomaticRepairsPolicy.Enabled
+				return !enabled, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-automatic-instance-repairs",
+		},
+		"vmss-010": {
+			Id:          "vmss-010",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityScaling,
+			Description: "Virtual Machine Scale Set in Uniform orchestration mode should enable overprovisioning",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				v := target.(*armcompute.VirtualMachineScaleSet)
+				if v.Properties == nil {
+					return false, ""
+				}
+				if v.Properties.OrchestrationMode != nil && *v.Properties.OrchestrationMode == armcompute.OrchestrationModeFlexible {
+					return false, ""
+				}
+				// Overprovisioning is enabled when the property is not set
+				disabled := v.Properties.Overprovision != nil && !*v.Properties.Overprovision
+				return disabled, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-design-overview#overprovisioning",
+		},
+		"vmss-011": {
+			Id:          "vmss-011",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "Virtual Machine Scale Set Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				c := target.(*armcompute.VirtualMachineScaleSet)
+				caf := strings.HasPrefix(*c.Name, "vmss")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"vmss-012": {
+			Id:          "vmss-012",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "Virtual Machine Scale Set should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				c := target.(*armcompute.VirtualMachineScaleSet)
+				return len(c.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
+
+// hasHealthMonitoring - Returns true if the scale set has the Application Health extension or a load balancer health probe
+func hasHealthMonitoring(v *armcompute.VirtualMachineScaleSet) bool {
+	if v.Properties == nil || v.Properties.VirtualMachineProfile == nil {
+		return false
+	}
+	profile := v.Properties.VirtualMachineProfile
+
+	if profile.NetworkProfile != nil && profile.NetworkProfile.HealthProbe != nil && profile.NetworkProfile.HealthProbe.ID != nil {
+		return true
+	}
+
+	if profile.ExtensionProfile != nil {
+		for _, e := range profile.ExtensionProfile.Extensions {
+			if e.Properties != nil && e.Properties.Type != nil && strings.HasPrefix(strings.ToLower(*e.Properties.Type), "applicationhealth") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,301 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package vmss
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/ref"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+func TestVirtualMachineScaleSetScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "VirtualMachineScaleSetScanner DiagnosticSettings",
+			fields: fields{
+				rule: "vmss-001",
+				target: &armcompute.VirtualMachineScaleSet{
+					ID: ref.Of("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner Availability Zones",
+			fields: fields{
+				rule: "vmss-002",
+				target: &armcompute.VirtualMachineScaleSet{
+					Zones: []*string{ref.Of("1"), ref.Of("2"), ref.Of("3")},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner SLA 99.95%",
+			fields: fields{
+				rule:        "vmss-003",
+				target:      &armcompute.VirtualMachineScaleSet{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.95%",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner SLA 99.99%",
+			fields: fields{
+				rule: "vmss-003",
+				target: &armcompute.VirtualMachineScaleSet{
+					Zones: []*string{ref.Of("1"), ref.Of("2")},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.99%",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner zones not balanced",
+			fields: fields{
+				rule: "vmss-004",
+				target: &armcompute.VirtualMachineScaleSet{
+					Zones: []*string{ref.Of("1"), ref.Of("2")},
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						ZoneBalance: ref.Of(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner zone balance without zones",
+			fields: fields{
+				rule:        "vmss-004",
+				target:      &armcompute.VirtualMachineScaleSet{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner Flexible orchestration mode",
+			fields: fields{
+				rule: "vmss-005",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						OrchestrationMode: ref.Of(armcompute.OrchestrationModeFlexible),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Flexible",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner Manual upgrade policy",
+			fields: fields{
+				rule: "vmss-006",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						UpgradePolicy: &armcompute.UpgradePolicy{
+							Mode: ref.Of(armcompute.UpgradeModeManual),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Manual",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner automatic OS upgrades",
+			fields: fields{
+				rule: "vmss-007",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						UpgradePolicy: &armcompute.UpgradePolicy{
+							AutomaticOSUpgradePolicy: &armcompute.AutomaticOSUpgradePolicy{
+								EnableAutomaticOSUpgrade: ref.Of(true),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner Application Health extension",
+			fields: fields{
+				rule: "vmss-008",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						VirtualMachineProfile: &armcompute.VirtualMachineScaleSetVMProfile{
+							ExtensionProfile: &armcompute.VirtualMachineScaleSetExtensionProfile{
+								Extensions: []*armcompute.VirtualMachineScaleSetExtension{
+									{
+										Properties: &armcompute.VirtualMachineScaleSetExtensionProperties{
+											Type: ref.Of("ApplicationHealthLinux"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner without health monitoring",
+			fields: fields{
+				rule: "vmss-008",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						VirtualMachineProfile: &armcompute.VirtualMachineScaleSetVMProfile{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner automatic repairs disabled",
+			fields: fields{
+				rule: "vmss-009",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner overprovisioning disabled",
+			fields: fields{
+				rule: "vmss-010",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						Overprovision: ref.Of(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner overprovisioning in Flexible orchestration mode",
+			fields: fields{
+				rule: "vmss-010",
+				target: &armcompute.VirtualMachineScaleSet{
+					Properties: &armcompute.VirtualMachineScaleSetProperties{
+						OrchestrationMode: ref.Of(armcompute.OrchestrationModeFlexible),
+						Overprovision:     ref.Of(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner CAF",
+			fields: fields{
+				rule: "vmss-011",
+				target: &armcompute.VirtualMachineScaleSet{
+					Name: ref.Of("vmss-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScaleSetScanner without tags",
+			fields: fields{
+				rule:        "vmss-012",
+				target:      &armcompute.VirtualMachineScaleSet{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &VirtualMachineScaleSetScanner{}
+			rules := s.GetRules()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VirtualMachineScaleSetScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
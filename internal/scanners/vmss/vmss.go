@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package vmss
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+)
+
+// VirtualMachineScaleSetScanner - Scanner for Virtual Machine Scale Sets
+type VirtualMachineScaleSetScanner struct {
+	config *scanners.ScannerConfig
+	client *armcompute.VirtualMachineScaleSetsClient
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "vmss",
+		Description: "Azure Virtual Machine Scale Sets",
+		ResourceTypes: []string{
+			"Microsoft.Compute/virtualMachineScaleSets",
+		},
+		New: func() scanners.IAzureScanner { return &VirtualMachineScaleSetScanner{} },
+	})
+}
+
+// Init - Initializes the VirtualMachineScaleSetScanner
+func (c *VirtualMachineScaleSetScanner) Init(config *scanners.ScannerConfig) error {
+	c.config = config
+	var err error
+	c.client, err = armcompute.NewVirtualMachineScaleSetsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Virtual Machine Scale Sets in a Resource Group
+func (c *VirtualMachineScaleSetScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning Virtual Machine Scale Sets in Resource Group %s", resourceGroupName)
+
+	scaleSets, err := c.list(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range scaleSets {
+		rr := engine.EvaluateRules(rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: c.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
+			ServiceName:    *w.Name,
+			ID:             *w.ID,
+			Type:           *w.Type,
+			Location:       *w.Location,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+func (c *VirtualMachineScaleSetScanner) list(resourceGroupName string) ([]*armcompute.VirtualMachineScaleSet, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armcompute.VirtualMachineScaleSet](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Compute/virtualMachineScaleSets")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListAllPager(nil)
+
+		scaleSets := make([]*armcompute.VirtualMachineScaleSet, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			scaleSets = append(scaleSets, resp.Value...)
+		}
+		return scaleSets, nil
+	}
+
+	pager := c.client.NewListPager(resourceGroupName, nil)
+
+	scaleSets := make([]*armcompute.VirtualMachineScaleSet, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		scaleSets = append(scaleSets, resp.Value...)
+	}
+	return scaleSets, nil
+}
@@ -16,6 +16,17 @@ type VirtualNetworkScanner struct {
 	client *armnetwork.VirtualNetworksClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "vnet",
+		Description: "Azure Virtual Network",
+		ResourceTypes: []string{
+			"Microsoft.Network/virtualNetworks",
+		},
+		New: func() scanners.IAzureScanner { return &VirtualNetworkScanner{} },
+	})
+}
+
 // Init - Initializes the VirtualNetwork
 func (c *VirtualNetworkScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *VirtualNetworkScanner) Scan(resourceGroupName string, scanContext *scan
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *VirtualNetworkScanner) Scan(resourceGroupName string, scanContext *scan
 }
 
 func (c *VirtualNetworkScanner) list(resourceGroupName string) ([]*armnetwork.VirtualNetwork, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.VirtualNetwork](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Network/virtualNetworks")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListAllPager(nil)
+
+		vnets := make([]*armnetwork.VirtualNetwork, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			vnets = append(vnets, resp.Value...)
+		}
+		return vnets, nil
+	}
+
 	pager := c.client.NewListPager(resourceGroupName, nil)
 
 	vnets := make([]*armnetwork.VirtualNetwork, 0)
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package vpng
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// GetRules - Returns the rules for the VPNGatewayScanner
+func (a *VPNGatewayScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"vpng-001": {
+			Id:          "vpng-001",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityDiagnosticLogs,
+			Description: "VPN Gateway should have diagnostic settings enabled",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				service := target.(*armnetwork.VirtualNetworkGateway)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/vpn-gateway/monitor-vpn-gateway",
+			Field: scanners.OverviewFieldDiagnostics,
+		},
+		"vpng-002": {
+			Id:          "vpng-002",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilityAvailabilityZones,
+			Description: "VPN Gateway should use a zone-redundant SKU",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return !scanners.IsZoneRedundantGateway(g), ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/vpn-gateway/about-zone-redundant-vnet-gateways",
+			Field: scanners.OverviewFieldAZ,
+		},
+		"vpng-003": {
+			Id:          "vpng-003",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySLA,
+			Description: "VPN Gateway SLA",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				sla := "99.95%"
+				if scanners.GetGatewaySKU(g) == armnetwork.VirtualNetworkGatewaySKUNameBasic {
+					sla = "99.9%"
+				} else if scanners.IsZoneRedundantGateway(g) {
+					sla = "99.99%"
+				}
+				return false, sla
+			},
+			Url:   "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
+			Field: scanners.OverviewFieldSLA,
+		},
+		"vpng-004": {
+			Id:          "vpng-004",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySKU,
+			Description: "VPN Gateway should not use the Basic SKU",
+			Severity:    scanners.SeverityHigh,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				sku := scanners.GetGatewaySKU(g)
+				return sku == armnetwork.VirtualNetworkGatewaySKUNameBasic, string(sku)
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/vpn-gateway/vpn-gateway-about-vpn-gateway-settings#gwsku",
+			Field: scanners.OverviewFieldSKU,
+		},
+		"vpng-005": {
+			Id:          "vpng-005",
+			Category:    scanners.RulesCategoryReliability,
+			Subcategory: scanners.RulesSubcategoryReliabilitySubcategoryReliability,
+			Description: "VPN Gateway should be configured in active-active mode",
+			Severity:    scanners.SeverityMedium,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				active := g.Properties != nil && g.Properties.Active != nil && *g.Properties.Active
+				return !active, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/active-active-portal",
+		},
+		"vpng-006": {
+			Id:          "vpng-006",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceCAF,
+			Description: "VPN Gateway Name should comply with naming conventions",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				caf := strings.HasPrefix(*g.Name, "vpng") || strings.HasPrefix(*g.Name, "vgw")
+				return !caf, ""
+			},
+			Url:   "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+			Field: scanners.OverviewFieldCAF,
+		},
+		"vpng-007": {
+			Id:          "vpng-007",
+			Category:    scanners.RulesCategoryOperationalExcellence,
+			Subcategory: scanners.RulesSubcategoryOperationalExcellenceTags,
+			Description: "VPN Gateway should have tags",
+			Severity:    scanners.SeverityLow,
+			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return len(g.Tags) == 0, ""
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
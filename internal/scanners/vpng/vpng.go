@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package vpng
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+)
+
+// VPNGatewayScanner - Scanner for VPN Gateways
+type VPNGatewayScanner struct {
+	config *scanners.ScannerConfig
+}
+
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "vpng",
+		Description: "Azure VPN Gateway",
+		ResourceTypes: []string{
+			"Microsoft.Network/virtualNetworkGateways",
+		},
+		New: func() scanners.IAzureScanner { return &VPNGatewayScanner{} },
+	})
+}
+
+// Init - Initializes the VPN Gateway Scanner
+func (a *VPNGatewayScanner) Init(config *scanners.ScannerConfig) error {
+	a.config = config
+	return nil
+}
+
+// Scan - Scans all VPN Gateways in a Resource Group
+func (a *VPNGatewayScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	log.Info().Msgf("Scanning VPN Gateways in Resource Group %s", resourceGroupName)
+
+	gateways, err := a.list(resourceGroupName, scanContext)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := a.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, g := range gateways {
+		rr := engine.EvaluateRules(rules, g, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID: a.config.SubscriptionID,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*g.ID),
+			Location:       *g.Location,
+			Type:           *g.Type,
+			ServiceName:    *g.Name,
+			ID:             *g.ID,
+			Rules:          rr,
+		})
+	}
+	return results, nil
+}
+
+// list - Returns the Virtual Network Gateways of type Vpn
+func (a *VPNGatewayScanner) list(resourceGroupName string, scanContext *scanners.ScanContext) ([]*armnetwork.VirtualNetworkGateway, error) {
+	return scanners.ListVirtualNetworkGateways(a.config, scanContext, resourceGroupName, armnetwork.VirtualNetworkGatewayTypeVPN)
+}
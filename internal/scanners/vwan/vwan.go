@@ -16,6 +16,17 @@ type VirtualWanScanner struct {
 	client *armnetwork.VirtualWansClient
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "vwan",
+		Description: "Azure Virtual WAN",
+		ResourceTypes: []string{
+			"Microsoft.Network/virtualWans",
+		},
+		New: func() scanners.IAzureScanner { return &VirtualWanScanner{} },
+	})
+}
+
 // Init - Initializes the VirtualWanScanner
 func (c *VirtualWanScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *VirtualWanScanner) Scan(resourceGroupName string, scanContext *scanners
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *VirtualWanScanner) Scan(resourceGroupName string, scanContext *scanners
 }
 
 func (c *VirtualWanScanner) list(resourceGroupName string) ([]*armnetwork.VirtualWAN, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armnetwork.VirtualWAN](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.Network/virtualWans")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListPager(nil)
+
+		vwans := make([]*armnetwork.VirtualWAN, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			vwans = append(vwans, resp.Value...)
+		}
+		return vwans, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	vwans := make([]*armnetwork.VirtualWAN, 0)
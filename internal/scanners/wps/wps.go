@@ -16,6 +16,17 @@ type WebPubSubScanner struct {
 	client *armwebpubsub.Client
 }
 
+func init() {
+	scanners.RegisterScanner(scanners.ScannerRegistration{
+		Name:        "wps",
+		Description: "Azure Web PubSub",
+		ResourceTypes: []string{
+			"Microsoft.SignalRService/WebPubSub",
+		},
+		New: func() scanners.IAzureScanner { return &WebPubSubScanner{} },
+	})
+}
+
 // Init - Initializes the WebPubSubScanner
 func (c *WebPubSubScanner) Init(config *scanners.ScannerConfig) error {
 	c.config = config
@@ -41,8 +52,9 @@ func (c *WebPubSubScanner) Scan(resourceGroupName string, scanContext *scanners.
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
-			ResourceGroup:  resourceGroupName,
+			ResourceGroup:  scanners.GetResourceGroupFromResourceID(*w.ID),
 			ServiceName:    *w.Name,
+			ID:             *w.ID,
 			Type:           *w.Type,
 			Location:       *w.Location,
 			Rules:          rr,
@@ -52,6 +64,24 @@ func (c *WebPubSubScanner) Scan(resourceGroupName string, scanContext *scanners.
 }
 
 func (c *WebPubSubScanner) listWebPubSub(resourceGroupName string) ([]*armwebpubsub.ResourceInfo, error) {
+	if c.config.Snapshot != nil {
+		return scanners.ListFromSnapshot[armwebpubsub.ResourceInfo](c.config.Snapshot, c.config.SubscriptionID, resourceGroupName, "Microsoft.SignalRService/WebPubSub")
+	}
+
+	if resourceGroupName == "" {
+		pager := c.client.NewListBySubscriptionPager(nil)
+
+		WebPubSubs := make([]*armwebpubsub.ResourceInfo, 0)
+		for pager.More() {
+			resp, err := pager.NextPage(c.config.Ctx)
+			if err != nil {
+				return nil, err
+			}
+			WebPubSubs = append(WebPubSubs, resp.Value...)
+		}
+		return WebPubSubs, nil
+	}
+
 	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
 
 	WebPubSubs := make([]*armwebpubsub.ResourceInfo, 0)